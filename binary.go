@@ -0,0 +1,172 @@
+package version
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// binaryFormatV1 is the first version of the binary encoding.
+const binaryFormatV1 byte = 1
+
+// Field tags used in the binary encoding. Tags must never be reused;
+// decoders skip tags they do not know.
+const (
+	binVersion byte = iota + 1
+	binCommit
+	binBuildDate
+	binBranch
+	binGoVersion
+	binPlatform
+	binCompiler
+	binDirty
+	binCompileDate
+	binRepository
+	binPipelineID
+	binBuildNumber
+	binNumCPU
+	binMaxProcs
+	binSupportedUntil
+	binExtra
+	binCodename
+	binLicense
+	binGoAMD64
+	binGoARM
+	binCommitMessage
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+//
+// The encoding starts with a format byte followed by tag-length-value
+// records: a one-byte field tag, a uvarint length and the value bytes.
+// Empty fields are omitted. Extra is stored as JSON.
+func (i *Info) MarshalBinary() ([]byte, error) {
+	buf := []byte{binaryFormatV1}
+
+	put := func(tag byte, value []byte) {
+		if len(value) == 0 {
+			return
+		}
+		buf = append(buf, tag)
+		buf = binary.AppendUvarint(buf, uint64(len(value)))
+		buf = append(buf, value...)
+	}
+	putInt := func(tag byte, value int) {
+		if value != 0 {
+			put(tag, binary.AppendVarint(nil, int64(value)))
+		}
+	}
+
+	put(binVersion, []byte(i.Version))
+	put(binCommit, []byte(i.Commit))
+	put(binBuildDate, []byte(i.BuildDate))
+	put(binBranch, []byte(i.Branch))
+	put(binGoVersion, []byte(i.GoVersion))
+	put(binPlatform, []byte(i.Platform))
+	put(binCompiler, []byte(i.Compiler))
+	if i.Dirty {
+		put(binDirty, []byte{1})
+	}
+	put(binCompileDate, []byte(i.CompileDate))
+	put(binRepository, []byte(i.Repository))
+	put(binPipelineID, []byte(i.PipelineID))
+	put(binBuildNumber, []byte(i.BuildNumber))
+	putInt(binNumCPU, i.NumCPU)
+	putInt(binMaxProcs, i.MaxProcs)
+	put(binSupportedUntil, []byte(i.SupportedUntil))
+	put(binCodename, []byte(i.Codename))
+	put(binLicense, []byte(i.License))
+	put(binGoAMD64, []byte(i.GoAMD64))
+	put(binGoARM, []byte(i.GoARM))
+	put(binCommitMessage, []byte(i.CommitMessage))
+
+	if len(i.Extra) > 0 {
+		extra, err := json.Marshal(i.Extra)
+		if err != nil {
+			return nil, fmt.Errorf("encode extra: %w", err)
+		}
+		put(binExtra, extra)
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Unknown field
+// tags are skipped so newer encodings can still be read.
+func (i *Info) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty binary version data")
+	}
+	if data[0] != binaryFormatV1 {
+		return fmt.Errorf("unsupported binary format %d", data[0])
+	}
+
+	var decoded Info
+	rest := data[1:]
+	for len(rest) > 0 {
+		tag := rest[0]
+		length, n := binary.Uvarint(rest[1:])
+		if n <= 0 || length > uint64(len(rest)-1-n) {
+			return errors.New("truncated binary version data")
+		}
+		value := rest[1+n : 1+n+int(length)]
+		rest = rest[1+n+int(length):]
+
+		switch tag {
+		case binVersion:
+			decoded.Version = string(value)
+		case binCommit:
+			decoded.Commit = string(value)
+		case binBuildDate:
+			decoded.BuildDate = string(value)
+		case binBranch:
+			decoded.Branch = string(value)
+		case binGoVersion:
+			decoded.GoVersion = string(value)
+		case binPlatform:
+			decoded.Platform = string(value)
+		case binCompiler:
+			decoded.Compiler = string(value)
+		case binDirty:
+			decoded.Dirty = len(value) > 0 && value[0] != 0
+		case binCompileDate:
+			decoded.CompileDate = string(value)
+		case binRepository:
+			decoded.Repository = string(value)
+		case binPipelineID:
+			decoded.PipelineID = string(value)
+		case binBuildNumber:
+			decoded.BuildNumber = string(value)
+		case binNumCPU, binMaxProcs:
+			v, m := binary.Varint(value)
+			if m <= 0 {
+				return fmt.Errorf("invalid integer for field %d", tag)
+			}
+			if tag == binNumCPU {
+				decoded.NumCPU = int(v)
+			} else {
+				decoded.MaxProcs = int(v)
+			}
+		case binSupportedUntil:
+			decoded.SupportedUntil = string(value)
+		case binCodename:
+			decoded.Codename = string(value)
+		case binLicense:
+			decoded.License = string(value)
+		case binGoAMD64:
+			decoded.GoAMD64 = string(value)
+		case binGoARM:
+			decoded.GoARM = string(value)
+		case binCommitMessage:
+			decoded.CommitMessage = string(value)
+		case binExtra:
+			if err := json.Unmarshal(value, &decoded.Extra); err != nil {
+				return fmt.Errorf("decode extra: %w", err)
+			}
+		}
+	}
+
+	*i = decoded
+	return nil
+}
@@ -0,0 +1,80 @@
+package version
+
+import (
+	"encoding"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Info)(nil)
+	_ encoding.BinaryUnmarshaler = (*Info)(nil)
+)
+
+func TestInfo_MarshalBinary_RoundTrip(t *testing.T) {
+	info := NewBuilder().
+		WithVersion("1.2.3").
+		WithCodename("Fuji").
+		WithLicense("MIT").
+		WithCommit("abc1234567890").
+		WithCommitMessage("Fix crash on startup").
+		WithBuildDate("2025-01-01T00:00:00Z").
+		WithBranch("功能/分支-ß").
+		WithDirty(true).
+		WithRuntimeInfo().
+		WithExtra("region", "eu-west-1").
+		Build()
+
+	data, err := info.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, binaryFormatV1, data[0])
+
+	var decoded Info
+	require.NoError(t, decoded.UnmarshalBinary(data))
+
+	assert.Equal(t, info, &decoded)
+	assert.Equal(t, "功能/分支-ß", decoded.Branch)
+}
+
+func TestInfo_MarshalBinary_Compact(t *testing.T) {
+	data, err := (&Info{Version: "1.0.0"}).MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{binaryFormatV1, binVersion, 5, '1', '.', '0', '.', '0'}, data)
+}
+
+func TestInfo_UnmarshalBinary_SkipsUnknownTags(t *testing.T) {
+	data := []byte{binaryFormatV1, 200, 2, 'x', 'y', binVersion, 1, '2'}
+
+	var decoded Info
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, "2", decoded.Version)
+}
+
+func TestInfo_UnmarshalBinary_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"unknown format", []byte{99}},
+		{"truncated length", []byte{binaryFormatV1, binVersion}},
+		{"truncated value", []byte{binaryFormatV1, binVersion, 5, '1'}},
+		{"bad extra", []byte{binaryFormatV1, binExtra, 1, '{'}},
+		{"bad integer", []byte{binaryFormatV1, binNumCPU, 1, 0x80}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded Info
+			assert.Error(t, decoded.UnmarshalBinary(tt.data))
+		})
+	}
+}
+
+func TestInfo_MarshalBinary_ExtraError(t *testing.T) {
+	info := &Info{Version: "1.0.0", Extra: map[string]any{"ch": make(chan int)}}
+	_, err := info.MarshalBinary()
+	assert.Error(t, err)
+}
@@ -0,0 +1,33 @@
+package version
+
+import (
+	"fmt"
+	"io"
+)
+
+// RunCompareCLI implements a "compare" subcommand. args are the arguments
+// after the subcommand name and must be two versions. It prints whether
+// the first version is "older", "equal" or "newer" than the second and
+// returns 0, or prints an error and returns 1.
+func RunCompareCLI(args []string, w io.Writer) int {
+	if len(args) != 2 {
+		_, _ = fmt.Fprintln(w, "usage: compare <version> <version>")
+		return 1
+	}
+
+	cmp, err := Compare(args[0], args[1])
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "error: %v\n", err)
+		return 1
+	}
+
+	switch cmp {
+	case -1:
+		_, _ = fmt.Fprintln(w, "older")
+	case 1:
+		_, _ = fmt.Fprintln(w, "newer")
+	default:
+		_, _ = fmt.Fprintln(w, "equal")
+	}
+	return 0
+}
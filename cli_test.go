@@ -0,0 +1,41 @@
+package version
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCompareCLI(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantOut  string
+		wantCode int
+	}{
+		{"older", []string{"1.2.0", "1.3.0"}, "older\n", 0},
+		{"newer", []string{"v2.0.0", "1.9.9"}, "newer\n", 0},
+		{"equal", []string{"1.2.0+a", "v1.2.0"}, "equal\n", 0},
+		{"prerelease older", []string{"1.0.0-rc.1", "1.0.0"}, "older\n", 0},
+		{"missing args", []string{"1.0.0"}, "usage: compare <version> <version>\n", 1},
+		{"too many args", []string{"1.0.0", "1.0.0", "1.0.0"}, "usage: compare <version> <version>\n", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			code := RunCompareCLI(tt.args, &buf)
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantOut, buf.String())
+		})
+	}
+}
+
+func TestRunCompareCLI_InvalidVersion(t *testing.T) {
+	var buf bytes.Buffer
+	code := RunCompareCLI([]string{"dev", "1.0.0"}, &buf)
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, buf.String(), "error:")
+}
@@ -0,0 +1,64 @@
+package version
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Dynamic holds version information that can be replaced at runtime, e.g.
+// on hot reload. Get and Set are lock-free and safe for concurrent use.
+// The zero value reports Default().
+type Dynamic struct {
+	info atomic.Pointer[Info]
+}
+
+// NewDynamic returns a Dynamic initially reporting info.
+func NewDynamic(info *Info) *Dynamic {
+	d := &Dynamic{}
+	d.Set(info)
+	return d
+}
+
+// Get returns the current Info, or Default() if none has been set.
+// The returned Info must not be modified.
+func (d *Dynamic) Get() *Info {
+	if info := d.info.Load(); info != nil {
+		return info
+	}
+	return Default()
+}
+
+// Set replaces the current Info. A copy is stored, so later changes to
+// info are not visible until Set is called again. Nil resets to Default().
+func (d *Dynamic) Set(info *Info) {
+	if info == nil {
+		d.info.Store(nil)
+		return
+	}
+	d.info.Store(info.clone())
+}
+
+// Handler returns a version Handler that serves the current Info on each
+// request. The Info and Provider fields of config are ignored.
+func (d *Dynamic) Handler(config ...HandlerConfig) http.HandlerFunc {
+	return Handler(d.config(config))
+}
+
+// FiberHandler returns a version FiberHandler that serves the current Info
+// on each request. The Info and Provider fields of config are ignored.
+func (d *Dynamic) FiberHandler(config ...HandlerConfig) fiber.Handler {
+	return FiberHandler(d.config(config))
+}
+
+// config returns the handler config reading from d.
+func (d *Dynamic) config(config []HandlerConfig) HandlerConfig {
+	cfg := DefaultHandlerConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	cfg.Info = d.Get()
+	cfg.Provider = d.Get
+	return cfg
+}
@@ -0,0 +1,83 @@
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamic_GetSet(t *testing.T) {
+	var d Dynamic
+	assert.Equal(t, Default().Version, d.Get().Version)
+
+	info := New("1.0.0", "", "")
+	d.Set(info)
+	info.Version = "mutated"
+	assert.Equal(t, "1.0.0", d.Get().Version)
+
+	d.Set(nil)
+	assert.Equal(t, Default().Version, d.Get().Version)
+}
+
+func TestDynamic_Handler(t *testing.T) {
+	d := NewDynamic(New("1.0.0", "", ""))
+	handler := d.Handler()
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Contains(t, w.Body.String(), `"version":"1.0.0"`)
+
+	d.Set(New("2.0.0", "", ""))
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Contains(t, w.Body.String(), `"version":"2.0.0"`)
+}
+
+func TestDynamic_FiberHandler(t *testing.T) {
+	d := NewDynamic(New("1.0.0", "", ""))
+	app := fiber.New()
+	app.Get("/version", d.FiberHandler())
+
+	d.Set(New("2.0.0", "", ""))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	var parsed Info
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&parsed))
+	assert.Equal(t, "2.0.0", parsed.Version)
+}
+
+func TestDynamic_Concurrent(t *testing.T) {
+	d := NewDynamic(New("0.0.0", "", ""))
+	handler := d.Handler()
+
+	var wg sync.WaitGroup
+	for n := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				d.Set(New(fmt.Sprintf("%d.%d.0", n, i), "", ""))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				w := httptest.NewRecorder()
+				handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+				var parsed Info
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+				assert.NotEmpty(t, parsed.Version)
+			}
+		}()
+	}
+	wg.Wait()
+}
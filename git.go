@@ -0,0 +1,89 @@
+package version
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GitOptions configures FromGit.
+type GitOptions struct {
+	// Binary is the git executable to run.
+	// Default: "git"
+	Binary string
+
+	// Dir is the repository directory. Empty means the current directory.
+	// Default: ""
+	Dir string
+}
+
+// commandRunner runs an external command and returns its standard output.
+type commandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+// execRunner runs commands with os/exec.
+func execRunner(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output()
+}
+
+// FromGit creates an Info by querying the git repository: the commit from
+// HEAD, the version from the nearest tag (git describe), the branch, the
+// commit time as BuildDate, the commit subject and whether the working
+// tree is dirty.
+// This is intended for development builds where ldflags were not set.
+func FromGit(ctx context.Context, opts ...GitOptions) (*Info, error) {
+	var opt GitOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return fromGit(ctx, opt, execRunner)
+}
+
+// fromGit implements FromGit with an injectable command runner.
+func fromGit(ctx context.Context, opt GitOptions, run commandRunner) (*Info, error) {
+	if opt.Binary == "" {
+		opt.Binary = "git"
+	}
+
+	git := func(args ...string) (string, error) {
+		if opt.Dir != "" {
+			args = append([]string{"-C", opt.Dir}, args...)
+		}
+		out, err := run(ctx, opt.Binary, args...)
+		return strings.TrimSpace(string(out)), err
+	}
+
+	commit, err := git("rev-parse", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("git rev-parse HEAD: %w", err)
+	}
+	if commit == "" {
+		return nil, fmt.Errorf("git rev-parse HEAD: empty output")
+	}
+
+	version, err := git("describe", "--tags", "--always")
+	if err != nil || version == "" || version == commit || strings.HasPrefix(commit, version) {
+		// No tags reachable from HEAD
+		version = "dev"
+	}
+
+	info := New(version, commit, "")
+
+	if branch, err := git("rev-parse", "--abbrev-ref", "HEAD"); err == nil && branch != "HEAD" {
+		info.Branch = branch
+	}
+
+	if date, err := git("log", "-1", "--format=%cI"); err == nil {
+		info.BuildDate = date
+	}
+
+	if subject, err := git("log", "-1", "--pretty=%s"); err == nil {
+		info.CommitMessage = subject
+	}
+
+	if status, err := git("status", "--porcelain"); err == nil {
+		info.Dirty = status != ""
+	}
+
+	return info, nil
+}
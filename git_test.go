@@ -0,0 +1,86 @@
+package version
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunner returns canned output keyed by the joined command line.
+func fakeRunner(outputs map[string]string, calls *[]string) commandRunner {
+	return func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		cmd := name + " " + strings.Join(args, " ")
+		if calls != nil {
+			*calls = append(*calls, cmd)
+		}
+		out, ok := outputs[cmd]
+		if !ok {
+			return nil, errors.New("unexpected command: " + cmd)
+		}
+		return []byte(out), nil
+	}
+}
+
+func TestFromGit_FakeRunner(t *testing.T) {
+	const commit = "0123456789abcdef0123456789abcdef01234567"
+
+	var calls []string
+	run := fakeRunner(map[string]string{
+		"/usr/bin/git -C /src rev-parse HEAD":              commit + "\n",
+		"/usr/bin/git -C /src describe --tags --always":    "v1.2.3\n",
+		"/usr/bin/git -C /src rev-parse --abbrev-ref HEAD": "main\n",
+		"/usr/bin/git -C /src log -1 --format=%cI":         "2025-01-01T12:00:00+00:00\n",
+		"/usr/bin/git -C /src log -1 --pretty=%s":          "Add release notes\n",
+		"/usr/bin/git -C /src status --porcelain":          " M version.go\n",
+	}, &calls)
+
+	info, err := fromGit(context.Background(), GitOptions{Binary: "/usr/bin/git", Dir: "/src"}, run)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.2.3", info.Version)
+	assert.Equal(t, commit, info.Commit)
+	assert.Equal(t, "main", info.Branch)
+	assert.Equal(t, "2025-01-01T12:00:00+00:00", info.BuildDate)
+	assert.Equal(t, "Add release notes", info.CommitMessage)
+	assert.True(t, info.Dirty)
+	assert.NotEmpty(t, info.GoVersion)
+	assert.Len(t, calls, 6)
+}
+
+func TestFromGit_NoTagsDetachedClean(t *testing.T) {
+	const commit = "0123456789abcdef0123456789abcdef01234567"
+
+	run := fakeRunner(map[string]string{
+		"git rev-parse HEAD":              commit,
+		"git describe --tags --always":    "0123456",
+		"git rev-parse --abbrev-ref HEAD": "HEAD",
+		"git log -1 --format=%cI":         "2025-01-01T12:00:00Z",
+		"git status --porcelain":          "",
+	}, nil)
+
+	info, err := fromGit(context.Background(), GitOptions{}, run)
+	require.NoError(t, err)
+
+	assert.Equal(t, "dev", info.Version)
+	assert.Empty(t, info.Branch)
+	assert.False(t, info.Dirty)
+}
+
+func TestFromGit_RevParseError(t *testing.T) {
+	_, err := fromGit(context.Background(), GitOptions{}, fakeRunner(map[string]string{}, nil))
+	assert.Error(t, err)
+
+	_, err = fromGit(context.Background(), GitOptions{}, fakeRunner(map[string]string{
+		"git rev-parse HEAD": "",
+	}, nil))
+	assert.Error(t, err)
+}
+
+func TestFromGit_MissingBinary(t *testing.T) {
+	_, err := FromGit(context.Background(), GitOptions{Binary: "/nonexistent/git"})
+	assert.Error(t, err)
+}
@@ -1,11 +1,24 @@
 package version
 
 import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
 	"net/http"
 	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
 )
 
 // HandlerConfig configures the version endpoint handler.
@@ -18,6 +31,11 @@ type HandlerConfig struct {
 	// Default: false
 	Pretty bool
 
+	// PrettyForBrowsers pretty-prints JSON only for requests that look like
+	// they come from a browser (Accept includes text/html).
+	// Default: false
+	PrettyForBrowsers bool
+
 	// IncludeHeaders adds version info to response headers.
 	// Default: false
 	IncludeHeaders bool
@@ -25,8 +43,142 @@ type HandlerConfig struct {
 	// HeaderPrefix is the prefix for version headers.
 	// Default: "X-"
 	HeaderPrefix string
+
+	// HeaderFields restricts which version headers are set, by name
+	// without prefix: "Version", "Commit", "Branch", "Build-Date" and
+	// "Build-Number".
+	// Empty means all headers.
+	// Default: nil
+	HeaderFields []string
+
+	// CombinedHeader, when set, emits all version components in a single
+	// header with this name instead of separate prefixed headers, e.g.
+	// "X-App-Version: version=1.2.3; commit=abc1234; branch=main".
+	// Default: ""
+	CombinedHeader string
+
+	// LinkPath, when set, adds a Link header pointing at the version
+	// resource (e.g. `</version>; rel="version"`), plus a rel="source"
+	// link when Info.Repository is set.
+	// Default: ""
+	LinkPath string
+
+	// EchoRequestID copies the incoming X-Request-ID header into the
+	// response, generating a new ID when the request has none.
+	// Default: false
+	EchoRequestID bool
+
+	// Minimal serves only {"version":"..."} in JSON output, dropping all
+	// other fields and the NestRuntime, EpochBuildDate and FieldCase
+	// options. Transform still runs.
+	// Default: false
+	Minimal bool
+
+	// Redact removes runtime fields (Go version, platform, compiler, CPU),
+	// CI metadata (pipeline ID, build number, dirty flag), the source
+	// repository and Extra from every output of the handler, JSON and
+	// text alike.
+	// Default: false
+	Redact bool
+
+	// NestRuntime nests go_version, platform, compiler and the optional
+	// CPU and microarchitecture fields under a "runtime" object in JSON output.
+	// Default: false
+	NestRuntime bool
+
+	// EpochBuildDate adds a "build_epoch" field with the build date as Unix
+	// seconds. It is omitted when the build date cannot be parsed.
+	// Default: false
+	EpochBuildDate bool
+
+	// IncludeServerTime adds a "server_time" field with the current server
+	// time in RFC3339 format, letting clients estimate clock skew.
+	// Default: false
+	IncludeServerTime bool
+
+	// ServedBy adds a "served_by" field with the request Host and, when
+	// known, a "local_addr" field with the local address that accepted the
+	// connection, identifying which replica or bind answered.
+	// Default: false
+	ServedBy bool
+
+	// FieldCase selects the casing of JSON keys: FieldCaseSnake
+	// ("build_date") or FieldCaseCamel ("buildDate"). Keys inside Extra
+	// are left unchanged.
+	// Default: FieldCaseSnake
+	FieldCase string
+
+	// Transform, when set, receives the version info as a map before it is
+	// encoded, allowing fields to be added or removed per request without
+	// changing the shared Info. Only used by Handler.
+	// Default: nil
+	Transform func(r *http.Request, fields map[string]any)
+
+	// SignKey, when set, signs the response body exactly as sent (JSON, or
+	// MessagePack when negotiated) with HMAC-SHA256 and emits the
+	// hex-encoded signature in the X-Version-Signature header.
+	// Default: nil
+	SignKey []byte
+
+	// ReadyCheck, when set, is called for each request; while it returns
+	// false the handler responds 503 Service Unavailable.
+	// Default: nil
+	ReadyCheck func() bool
+
+	// Color makes TextHandler wrap field labels in ANSI bold escape codes
+	// for terminal clients such as curl. It is applied whenever set, as
+	// the handler cannot tell whether the client is a terminal.
+	// Default: false
+	Color bool
+
+	// RequireHeader, when its Name is set, hides the endpoint behind a
+	// header gate: requests without a matching header get 404 Not Found.
+	// Default: zero value (no gate)
+	RequireHeader RequiredHeader
+
+	// Provider, when set, is called for each request and its Info is served
+	// instead of the static Info, e.g. to follow blue/green switches. A nil
+	// result falls back to Info.
+	// Default: nil
+	Provider func() *Info
+
+	// DisableRecover turns off panic recovery. By default panics raised
+	// while building the response, for example from Transform or a custom
+	// MarshalJSON in Extra, are logged with slog and answered with 500
+	// instead of crashing the server.
+	// Default: false
+	DisableRecover bool
+
+	// LastModified sets the Last-Modified header from the build date when
+	// it can be parsed, and answers GET and HEAD requests carrying an
+	// If-Modified-Since at or after it with 304 Not Modified.
+	// Default: false
+	LastModified bool
+
+	// WarnOnDev adds a `Warning: 199 - "development build"` header when
+	// Info.IsDev() is true.
+	// Default: false
+	WarnOnDev bool
+
+	// DevStatusCode is the HTTP status returned when Info.IsDev() is true.
+	// The version body is still written. Zero means always 200.
+	// Default: 0
+	DevStatusCode int
+}
+
+// RequiredHeader is a request header that must be present with the given
+// value for HandlerConfig.RequireHeader.
+type RequiredHeader struct {
+	Name  string
+	Value string
 }
 
+// JSON key casings for HandlerConfig.FieldCase.
+const (
+	FieldCaseSnake = "snake"
+	FieldCaseCamel = "camel"
+)
+
 // DefaultHandlerConfig returns a HandlerConfig with default values.
 func DefaultHandlerConfig() HandlerConfig {
 	return HandlerConfig{
@@ -37,7 +189,61 @@ func DefaultHandlerConfig() HandlerConfig {
 	}
 }
 
+// Preset names accepted by Preset.
+const (
+	PresetPublic   = "public"
+	PresetInternal = "internal"
+)
+
+// Preset returns a curated HandlerConfig for the named environment:
+// PresetPublic serves version, commit and dates only, dropping runtime
+// fields, CI metadata and Extra via Redact; PresetInternal exposes every
+// field and the version headers. Unknown names return
+// DefaultHandlerConfig(). Set Info on the result before use.
+func Preset(name string) HandlerConfig {
+	cfg := DefaultHandlerConfig()
+
+	switch name {
+	case PresetPublic:
+		cfg.Redact = true
+	case PresetInternal:
+		cfg.IncludeHeaders = true
+		cfg.EpochBuildDate = true
+	}
+	return cfg
+}
+
+// forRequest returns cfg with Info replaced by the Provider result, if any,
+// and redacted when Redact is set.
+func (cfg HandlerConfig) forRequest() HandlerConfig {
+	if cfg.Provider != nil {
+		if info := cfg.Provider(); info != nil {
+			cfg.Info = info
+		}
+	}
+	if cfg.Redact {
+		cfg.Info = cfg.Info.redacted()
+	}
+	return cfg
+}
+
+// hidden reports whether the request fails the RequireHeader gate. get
+// returns the value of the named request header.
+func (cfg HandlerConfig) hidden(get func(string) string) bool {
+	return cfg.RequireHeader.Name != "" && get(cfg.RequireHeader.Name) != cfg.RequireHeader.Value
+}
+
+// statusCode returns the HTTP status to respond with for the configured Info.
+func (cfg HandlerConfig) statusCode() int {
+	if cfg.DevStatusCode != 0 && cfg.Info.IsDev() {
+		return cfg.DevStatusCode
+	}
+	return http.StatusOK
+}
+
 // Handler returns an http.HandlerFunc that serves version information.
+// The body is JSON, or MessagePack when the Accept header asks for
+// application/msgpack.
 func Handler(config ...HandlerConfig) http.HandlerFunc {
 	cfg := DefaultHandlerConfig()
 	if len(config) > 0 {
@@ -53,31 +259,389 @@ func Handler(config ...HandlerConfig) http.HandlerFunc {
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
+		if !cfg.DisableRecover {
+			defer recoverJSON(w)
+		}
+		cfg := cfg.forRequest()
+
+		if cfg.hidden(r.Header.Get) {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Method == http.MethodOptions {
+			writeOptions(w)
+			return
+		}
+
+		if cfg.ReadyCheck != nil && !cfg.ReadyCheck() {
+			writeJSONError(w, http.StatusServiceUnavailable, "version not ready")
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 
-		if cfg.IncludeHeaders {
-			setVersionHeaders(w.Header(), cfg.Info, cfg.HeaderPrefix)
+		if cfg.CombinedHeader != "" {
+			w.Header().Set(cfg.CombinedHeader, combinedHeaderValue(cfg.Info))
+		} else if cfg.IncludeHeaders {
+			setVersionHeaders(w.Header(), cfg.Info, cfg.HeaderPrefix, cfg.HeaderFields)
+		}
+
+		if cfg.LinkPath != "" {
+			setLinkHeaders(w.Header(), cfg.Info, cfg.LinkPath)
+		}
+
+		if cfg.EchoRequestID {
+			w.Header().Set(RequestIDHeader, requestID(r.Header.Get(RequestIDHeader)))
+		}
+
+		if cfg.Info.IsEOL() {
+			w.Header().Add("Warning", eolWarning(cfg.Info))
+		}
+
+		if cfg.WarnOnDev && cfg.Info.IsDev() {
+			w.Header().Add("Warning", devWarning)
+		}
+
+		if cfg.LastModified && notModified(w.Header(), r.Method, r.Header.Get("If-Modified-Since"), cfg.Info) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		var payload any = cfg.Info
+		if cfg.Transform != nil || cfg.reshapes() {
+			fields, err := cfg.fields()
+			if err != nil {
+				writeJSONError(w, http.StatusInternalServerError, "failed to marshal version info: "+err.Error())
+				return
+			}
+			var localAddr string
+			if addr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr); ok {
+				localAddr = addr.String()
+			}
+			cfg.addServedBy(fields, r.Host, localAddr)
+			if cfg.Transform != nil {
+				cfg.Transform(r, fields)
+			}
+			payload = fields
 		}
 
 		var output []byte
 		var err error
 
-		if cfg.Pretty {
-			output, err = json.MarshalIndent(cfg.Info, "", "  ")
+		if wantsMsgpack(r) {
+			w.Header().Set("Content-Type", MsgpackContentType)
+			output, err = marshalMsgpack(payload)
+		} else if cfg.Pretty || wantsPretty(r) || (cfg.PrettyForBrowsers && isBrowser(r)) {
+			output, err = json.MarshalIndent(payload, "", "  ")
 		} else {
-			output, err = json.Marshal(cfg.Info)
+			output, err = json.Marshal(payload)
 		}
 
 		if err != nil {
-			http.Error(w, `{"error": "failed to marshal version info"}`, http.StatusInternalServerError)
+			writeJSONError(w, http.StatusInternalServerError, "failed to marshal version info: "+err.Error())
+			return
+		}
+
+		if len(cfg.SignKey) > 0 {
+			w.Header().Set(SignatureHeader, signPayload(cfg.SignKey, output))
+		}
+
+		w.WriteHeader(cfg.statusCode())
+		writeBody(w, output)
+	}
+}
+
+// infoFields converts info to a generic map using its JSON representation.
+func infoFields(info *Info) (map[string]any, error) {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// reshapes reports whether the JSON output differs from the plain Info.
+func (cfg HandlerConfig) reshapes() bool {
+	return cfg.Minimal || cfg.NestRuntime || cfg.EpochBuildDate || cfg.IncludeServerTime || cfg.ServedBy || cfg.FieldCase == FieldCaseCamel
+}
+
+// fields returns the JSON fields of the configured Info with the
+// Minimal, NestRuntime, EpochBuildDate, IncludeServerTime and FieldCase
+// options applied.
+func (cfg HandlerConfig) fields() (map[string]any, error) {
+	if cfg.Minimal {
+		return map[string]any{"version": cfg.Info.Version}, nil
+	}
+
+	fields, err := infoFields(cfg.Info)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.EpochBuildDate {
+		if ts := cfg.Info.BuildTimestamp(); !ts.IsZero() {
+			fields["build_epoch"] = ts.Unix()
+		}
+	}
+	if cfg.IncludeServerTime {
+		fields["server_time"] = now().UTC().Format(time.RFC3339)
+	}
+	if cfg.NestRuntime {
+		nestRuntimeFields(fields)
+	}
+	if cfg.FieldCase == FieldCaseCamel {
+		fields = camelCaseFields(fields)
+		if nested, ok := fields["runtime"].(map[string]any); ok {
+			fields["runtime"] = camelCaseFields(nested)
+		}
+	}
+	return fields, nil
+}
+
+// camelFieldNames maps snake_case JSON keys to their camelCase form.
+var camelFieldNames = map[string]string{
+	"commit_message":  "commitMessage",
+	"build_date":      "buildDate",
+	"compile_date":    "compileDate",
+	"supported_until": "supportedUntil",
+	"pipeline_id":     "pipelineId",
+	"build_number":    "buildNumber",
+	"go_version":      "goVersion",
+	"num_cpu":         "numCpu",
+	"max_procs":       "maxProcs",
+	"build_epoch":     "buildEpoch",
+	"server_time":     "serverTime",
+	"served_by":       "servedBy",
+	"local_addr":      "localAddr",
+}
+
+// addServedBy sets the ServedBy fields on fields, honouring FieldCase.
+func (cfg HandlerConfig) addServedBy(fields map[string]any, host, localAddr string) {
+	if !cfg.ServedBy {
+		return
+	}
+	key := func(name string) string {
+		if cfg.FieldCase == FieldCaseCamel {
+			return camelFieldNames[name]
+		}
+		return name
+	}
+	fields[key("served_by")] = host
+	if localAddr != "" {
+		fields[key("local_addr")] = localAddr
+	}
+}
+
+// camelCaseFields returns fields with known keys renamed to camelCase.
+func camelCaseFields(fields map[string]any) map[string]any {
+	renamed := make(map[string]any, len(fields))
+	for key, value := range fields {
+		if camel, ok := camelFieldNames[key]; ok {
+			key = camel
+		}
+		renamed[key] = value
+	}
+	return renamed
+}
+
+// runtimeFieldNames are the JSON fields moved under "runtime" by NestRuntime.
+var runtimeFieldNames = []string{"go_version", "platform", "compiler", "goamd64", "goarm", "num_cpu", "max_procs"}
+
+// nestRuntimeFields moves runtime fields into a nested "runtime" object.
+func nestRuntimeFields(fields map[string]any) {
+	nested := make(map[string]any)
+	for _, name := range runtimeFieldNames {
+		if value, ok := fields[name]; ok {
+			nested[name] = value
+			delete(fields, name)
+		}
+	}
+	if len(nested) > 0 {
+		fields["runtime"] = nested
+	}
+}
+
+// MultiHandler returns an http.HandlerFunc that serves a JSON object mapping
+// each name to its version information. Nil entries are skipped.
+// Only the Pretty option of the config is used.
+func MultiHandler(infos map[string]*Info, config ...HandlerConfig) http.HandlerFunc {
+	cfg := DefaultHandlerConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	entries := nonNilInfos(infos)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, cfg.Pretty, entries)
+	}
+}
+
+// APIVersionsHandler returns an http.HandlerFunc that serves the versions
+// of the API generations exposed by the server as
+// {"api_versions":{"v1":{...},"v2":{...}}}. Keys are sorted and nil
+// entries are skipped. Only the Pretty option of the config is used.
+func APIVersionsHandler(versions map[string]*Info, config ...HandlerConfig) http.HandlerFunc {
+	cfg := DefaultHandlerConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	body := map[string]map[string]*Info{"api_versions": nonNilInfos(versions)}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, cfg.Pretty, body)
+	}
+}
+
+// nonNilInfos returns a copy of infos without nil entries.
+func nonNilInfos(infos map[string]*Info) map[string]*Info {
+	entries := make(map[string]*Info, len(infos))
+	for name, info := range infos {
+		if info != nil {
+			entries[name] = info
+		}
+	}
+	return entries
+}
+
+// writeJSON writes v as a 200 JSON response, indented when pretty is set
+// or requested via the query string. Map keys are sorted by encoding/json.
+func writeJSON(w http.ResponseWriter, r *http.Request, pretty bool, v any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var output []byte
+	var err error
+
+	if pretty || wantsPretty(r) {
+		output, err = json.MarshalIndent(v, "", "  ")
+	} else {
+		output, err = json.Marshal(v)
+	}
+
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "failed to marshal version info: "+err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	writeBody(w, output)
+}
+
+// DefaultSSEInterval is the default interval between Server-Sent Events.
+const DefaultSSEInterval = 5 * time.Second
+
+// SSEHandler returns an http.HandlerFunc that streams version information as
+// Server-Sent Events. An event is sent immediately and then every interval
+// (DefaultSSEInterval if omitted or non-positive) until the request context
+// is cancelled. source is called for each event so callers can update the
+// reported version; if source is nil, Default() will be used.
+func SSEHandler(source func() *Info, interval ...time.Duration) http.HandlerFunc {
+	if source == nil {
+		source = Default
+	}
+
+	every := DefaultSSEInterval
+	if len(interval) > 0 && interval[0] > 0 {
+		every = interval[0]
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			writeJSONError(w, http.StatusInternalServerError, "streaming unsupported")
 			return
 		}
 
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
 		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write(output)
+
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+
+		for {
+			if err := writeSSEEvent(w, source()); err != nil {
+				return
+			}
+			flusher.Flush()
+
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// FiberSSEHandler returns a Fiber handler that streams version information
+// as Server-Sent Events, like SSEHandler. Fasthttp does not report client
+// disconnects, so streaming stops when a write to the client fails; a
+// keepalive comment is sent in place of a nil event so that every tick
+// reaches the connection.
+func FiberSSEHandler(source func() *Info, interval ...time.Duration) fiber.Handler {
+	if source == nil {
+		source = Default
+	}
+
+	every := DefaultSSEInterval
+	if len(interval) > 0 && interval[0] > 0 {
+		every = interval[0]
+	}
+
+	return func(c *fiber.Ctx) error {
+		c.Set("Content-Type", "text/event-stream")
+		c.Set("Cache-Control", "no-cache")
+		c.Set("Connection", "keep-alive")
+
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			ticker := time.NewTicker(every)
+			defer ticker.Stop()
+
+			for {
+				var err error
+				if info := source(); info != nil {
+					err = writeSSEEvent(w, info)
+				} else {
+					_, err = io.WriteString(w, sseKeepalive)
+				}
+				if err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+				<-ticker.C
+			}
+		})
+		return nil
 	}
 }
 
+// sseKeepalive is an SSE comment sent by FiberSSEHandler when there is no
+// event to write.
+const sseKeepalive = ": keepalive\n\n"
+
+// writeSSEEvent writes info as a single Server-Sent Event data frame.
+// Nil info is skipped.
+func writeSSEEvent(w io.Writer, info *Info) error {
+	if info == nil {
+		return nil
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "data: %s\n\n", data)
+	return err
+}
+
 // FiberHandler returns a Fiber handler that serves version information.
 func FiberHandler(config ...HandlerConfig) fiber.Handler {
 	cfg := DefaultHandlerConfig()
@@ -93,63 +657,506 @@ func FiberHandler(config ...HandlerConfig) fiber.Handler {
 		cfg.HeaderPrefix = "X-"
 	}
 
-	return func(c *fiber.Ctx) error {
+	return func(c *fiber.Ctx) (err error) {
+		if !cfg.DisableRecover {
+			defer func() {
+				if rec := recover(); rec != nil {
+					slog.Error("version handler panic", "panic", rec)
+					err = c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": "internal error"})
+				}
+			}()
+		}
+		cfg := cfg.forRequest()
+
+		if cfg.hidden(func(name string) string { return c.Get(name) }) {
+			return c.SendStatus(http.StatusNotFound)
+		}
+
+		if c.Method() == fiber.MethodOptions {
+			c.Set("Allow", allowedMethods)
+			return c.SendStatus(http.StatusNoContent)
+		}
+
+		if cfg.ReadyCheck != nil && !cfg.ReadyCheck() {
+			return c.Status(http.StatusServiceUnavailable).JSON(fiber.Map{"error": "version not ready"})
+		}
+
 		c.Set("Content-Type", "application/json")
 
-		if cfg.IncludeHeaders {
-			setVersionHeadersFiber(c, cfg.Info, cfg.HeaderPrefix)
+		if cfg.CombinedHeader != "" {
+			c.Set(cfg.CombinedHeader, combinedHeaderValue(cfg.Info))
+		} else if cfg.IncludeHeaders {
+			setVersionHeadersFiber(c, cfg.Info, cfg.HeaderPrefix, cfg.HeaderFields)
+		}
+
+		if cfg.LinkPath != "" {
+			for _, link := range linkValues(cfg.Info, cfg.LinkPath) {
+				c.Append(fiber.HeaderLink, link)
+			}
+		}
+
+		if cfg.EchoRequestID {
+			c.Set(RequestIDHeader, requestID(c.Get(RequestIDHeader)))
+		}
+
+		if cfg.Info.IsEOL() {
+			c.Append("Warning", eolWarning(cfg.Info))
+		}
+
+		if cfg.WarnOnDev && cfg.Info.IsDev() {
+			c.Append("Warning", devWarning)
+		}
+
+		if cfg.LastModified {
+			header := make(http.Header)
+			unchanged := notModified(header, c.Method(), c.Get("If-Modified-Since"), cfg.Info)
+			if value := header.Get("Last-Modified"); value != "" {
+				c.Set("Last-Modified", value)
+			}
+			if unchanged {
+				return c.SendStatus(http.StatusNotModified)
+			}
+		}
+
+		c.Status(cfg.statusCode())
+
+		var payload any = cfg.Info
+		if cfg.reshapes() {
+			fields, err := cfg.fields()
+			if err != nil {
+				return err
+			}
+			cfg.addServedBy(fields, c.Hostname(), c.Context().LocalAddr().String())
+			payload = fields
+		}
+
+		if len(cfg.SignKey) > 0 {
+			output, err := json.Marshal(payload)
+			if err != nil {
+				return err
+			}
+			c.Set(SignatureHeader, signPayload(cfg.SignKey, output))
+			return c.Send(output)
 		}
 
 		if cfg.Pretty {
-			return c.JSON(cfg.Info)
+			return c.JSON(payload)
 		}
 
-		return c.JSON(cfg.Info)
+		return c.JSON(payload)
+	}
+}
+
+// Badge is the shields.io endpoint badge schema.
+// See https://shields.io/badges/endpoint-badge
+type Badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// newBadge builds the shields.io badge for info.
+func newBadge(info *Info) Badge {
+	return Badge{
+		SchemaVersion: 1,
+		Label:         "version",
+		Message:       info.Version,
+		Color:         badgeColor(info),
+	}
+}
+
+// badgeColor returns the badge color for info: orange for development
+// builds, yellow for prereleases and blue for releases.
+func badgeColor(info *Info) string {
+	if info.IsDev() {
+		return "orange"
+	}
+	if v, err := parseSemver(info.Version); err == nil && len(v.prerelease) > 0 {
+		return "yellow"
+	}
+	return "blue"
+}
+
+// BadgeHandler returns an http.HandlerFunc that serves a shields.io endpoint badge.
+func BadgeHandler(config ...HandlerConfig) http.HandlerFunc {
+	cfg := DefaultHandlerConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	if cfg.Info == nil {
+		cfg.Info = Default()
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		cfg := cfg.forRequest()
+		output, err := json.Marshal(newBadge(cfg.Info))
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "failed to marshal badge: "+err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		writeBody(w, output)
+	}
+}
+
+// FiberBadgeHandler returns a Fiber handler that serves a shields.io endpoint badge.
+func FiberBadgeHandler(config ...HandlerConfig) fiber.Handler {
+	cfg := DefaultHandlerConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	if cfg.Info == nil {
+		cfg.Info = Default()
+	}
+
+	return func(c *fiber.Ctx) error {
+		return c.JSON(newBadge(cfg.forRequest().Info))
 	}
 }
 
 // RegisterEndpoint registers the version handler on an http.ServeMux.
+// Only requests for exactly path are served; deeper paths matched by a
+// trailing-slash subtree pattern receive 404 Not Found. When path has no
+// trailing slash and no wildcards, the trailing-slash variant is registered
+// as well and serves the same response, so "/version/" does not 404.
 func RegisterEndpoint(mux *http.ServeMux, path string, config ...HandlerConfig) {
-	mux.HandleFunc(path, Handler(config...))
+	handler := Handler(config...)
+	mux.HandleFunc(path, exactPath(path, handler))
+	if !strings.HasSuffix(path, "/") && !strings.Contains(path, "{") {
+		mux.HandleFunc(path+"/", exactPath(path+"/", handler))
+	}
+}
+
+// exactPath wraps next so that it only serves requests whose URL path equals
+// the path of pattern. Patterns may carry a method and host as accepted by
+// http.ServeMux; patterns with wildcards are not restricted.
+func exactPath(pattern string, next http.HandlerFunc) http.HandlerFunc {
+	path := pattern
+	if idx := strings.IndexByte(path, ' '); idx >= 0 {
+		path = strings.TrimSpace(path[idx+1:])
+	}
+	if idx := strings.IndexByte(path, '/'); idx > 0 {
+		path = path[idx:]
+	}
+	if strings.Contains(path, "{") {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		next(w, r)
+	}
 }
 
 // RegisterEndpointFiber registers the version handler on a Fiber app.
 func RegisterEndpointFiber(app *fiber.App, path string, config ...HandlerConfig) {
-	app.Get(path, FiberHandler(config...))
+	handler := FiberHandler(config...)
+	app.Get(path, handler)
+	app.Options(path, handler)
 }
 
-// setVersionHeaders adds version information to HTTP headers.
-func setVersionHeaders(h http.Header, info *Info, prefix string) {
-	h.Set(prefix+"Version", sanitizeHeaderValue(info.Version))
+// FiberStartupLog logs the version banner via Fiber's logger when the app
+// starts listening. The app name is taken from the Fiber config.
+// If info is nil, Default() will be used.
+func FiberStartupLog(app *fiber.App, info *Info) {
+	if info == nil {
+		info = Default()
+	}
+
+	var banner strings.Builder
+	LogBanner(&banner, app.Config().AppName, info)
+	message := strings.TrimRight(banner.String(), "\n")
+
+	app.Hooks().OnListen(func(fiber.ListenData) error {
+		log.Info(message)
+		return nil
+	})
+}
+
+// versionHeader is a version header name (without prefix) and its value.
+type versionHeader struct {
+	name  string
+	value string
+}
+
+// versionHeaders returns the version headers for info. If fields is
+// non-empty, only headers whose name matches an entry (case-insensitively)
+// are returned.
+func versionHeaders(info *Info, fields []string) []versionHeader {
+	var headers []versionHeader
+	add := func(name, value string) {
+		if len(fields) > 0 && !containsFold(fields, name) {
+			return
+		}
+		headers = append(headers, versionHeader{name: name, value: sanitizeHeaderValue(value)})
+	}
 
-	if info.Commit != "" && info.Commit != "unknown" {
-		h.Set(prefix+"Commit", sanitizeHeaderValue(info.ShortCommit()))
+	add("Version", info.Version)
+
+	if info.Commit != "" && info.Commit != Unknown {
+		add("Commit", info.ShortCommit())
 	}
 
+	limit := MaxHeaderValueLength()
+
 	if info.Branch != "" {
-		h.Set(prefix+"Branch", sanitizeHeaderValue(info.Branch))
+		add("Branch", truncateHeaderValue(info.Branch, limit))
+	}
+
+	if info.BuildDate != "" && info.BuildDate != Unknown {
+		add("Build-Date", truncateHeaderValue(info.BuildDate, limit))
+	}
+
+	if info.BuildNumber != "" {
+		add("Build-Number", truncateHeaderValue(info.BuildNumber, limit))
+	}
+
+	return headers
+}
+
+// DefaultMaxHeaderValueLength is the default maximum length in bytes of
+// the branch, build date and build number version headers.
+const DefaultMaxHeaderValueLength = 256
+
+// maxHeaderValueLength overrides DefaultMaxHeaderValueLength when positive.
+var maxHeaderValueLength atomic.Int32
+
+// SetMaxHeaderValueLength sets the maximum length in bytes of the branch,
+// build date and build number version headers; longer values are
+// truncated and end with "...". Values below 4 are raised to 4; n <= 0
+// restores DefaultMaxHeaderValueLength. The commit header is always short.
+func SetMaxHeaderValueLength(n int) {
+	if n > 0 && n < 4 {
+		n = 4
+	}
+	maxHeaderValueLength.Store(int32(max(n, 0)))
+}
+
+// MaxHeaderValueLength returns the current maximum version header length.
+func MaxHeaderValueLength() int {
+	if n := maxHeaderValueLength.Load(); n > 0 {
+		return int(n)
+	}
+	return DefaultMaxHeaderValueLength
+}
+
+// truncateHeaderValue shortens value to at most limit bytes, cutting on a
+// rune boundary and appending "...".
+func truncateHeaderValue(value string, limit int) string {
+	if len(value) <= limit {
+		return value
 	}
+	cut := limit - 3
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return value[:cut] + "..."
+}
 
-	if info.BuildDate != "" && info.BuildDate != "unknown" {
-		h.Set(prefix+"Build-Date", sanitizeHeaderValue(info.BuildDate))
+// containsFold reports whether list contains s, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// setVersionHeaders adds version information to HTTP headers.
+func setVersionHeaders(h http.Header, info *Info, prefix string, fields []string) {
+	for _, header := range versionHeaders(info, fields) {
+		h.Set(prefix+header.name, header.value)
 	}
 }
 
 // setVersionHeadersFiber adds version information to Fiber response headers.
-func setVersionHeadersFiber(c *fiber.Ctx, info *Info, prefix string) {
-	c.Set(prefix+"Version", sanitizeHeaderValue(info.Version))
+func setVersionHeadersFiber(c *fiber.Ctx, info *Info, prefix string, fields []string) {
+	for _, header := range versionHeaders(info, fields) {
+		c.Set(prefix+header.name, header.value)
+	}
+}
+
+// allowedMethods is the Allow header value for version endpoints.
+const allowedMethods = "GET, HEAD, OPTIONS"
 
-	if info.Commit != "" && info.Commit != "unknown" {
-		c.Set(prefix+"Commit", sanitizeHeaderValue(info.ShortCommit()))
+// writeOptions answers an OPTIONS request with 204 and the allowed methods.
+func writeOptions(w http.ResponseWriter) {
+	w.Header().Set("Allow", allowedMethods)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// recoverJSON recovers a panic in a handler, logs it and responds 500.
+// It must be deferred directly.
+func recoverJSON(w http.ResponseWriter) {
+	if rec := recover(); rec != nil {
+		slog.Error("version handler panic", "panic", rec)
+		writeJSONError(w, http.StatusInternalServerError, "internal error")
+	}
+}
+
+// writeBody writes the response body to w and flushes it when w implements
+// http.Flusher, so buffering ResponseWriter wrappers do not hold back the
+// response. Handlers write each body with a single Write call and rely on
+// nothing beyond the io.Writer contract for it.
+func writeBody(w http.ResponseWriter, data []byte) {
+	_, _ = w.Write(data)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// writeJSONError writes a JSON error body with the given status code.
+// Any previously set Content-Length is dropped and control characters are
+// stripped from the message.
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	output, _ := json.Marshal(map[string]string{"error": sanitizeHeaderValue(message)})
+
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "application/json")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	writeBody(w, output)
+}
+
+// eolWarning returns a Warning header value announcing that info is past
+// its end-of-life date.
+func eolWarning(info *Info) string {
+	message := fmt.Sprintf("version %s reached end of life on %s", info.Version, info.SupportedUntil)
+	return sanitizeHeaderValue(fmt.Sprintf("299 - %q", message))
+}
+
+// notModified sets Last-Modified in h from the build date of info and
+// reports whether a request with the given method and If-Modified-Since
+// value can be answered with 304 Not Modified.
+func notModified(h http.Header, method, ifModifiedSince string, info *Info) bool {
+	built := info.BuildTimestamp()
+	if built.IsZero() {
+		return false
 	}
+	built = built.Truncate(time.Second)
+	h.Set("Last-Modified", built.UTC().Format(http.TimeFormat))
+
+	if method != http.MethodGet && method != http.MethodHead || ifModifiedSince == "" {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	return err == nil && !built.After(since)
+}
+
+// devWarning is the Warning header value emitted by WarnOnDev.
+const devWarning = `199 - "development build"`
+
+// SignatureHeader carries the HMAC-SHA256 signature when SignKey is set.
+const SignatureHeader = "X-Version-Signature"
+
+// signPayload returns the hex-encoded HMAC-SHA256 of payload.
+func signPayload(key, payload []byte) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// RequestIDHeader is the header echoed when EchoRequestID is enabled.
+const RequestIDHeader = "X-Request-ID"
+
+// requestID returns the sanitized incoming request ID, or a newly
+// generated random ID if it is empty.
+func requestID(incoming string) string {
+	if id := sanitizeHeaderValue(incoming); id != "" {
+		return id
+	}
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// wantsPretty reports whether the request asks for indented JSON via the
+// "pretty" query parameter. Accepted values are "1" and "true".
+func wantsPretty(r *http.Request) bool {
+	switch strings.ToLower(r.URL.Query().Get("pretty")) {
+	case "1", "true":
+		return true
+	}
+	return false
+}
+
+// setLinkHeaders adds Link headers for the version resource and source.
+func setLinkHeaders(h http.Header, info *Info, path string) {
+	for _, link := range linkValues(info, path) {
+		h.Add("Link", link)
+	}
+}
+
+// linkValues returns the Link header values for the version resource at
+// path and, when Repository is set, the source (commit URL if known).
+func linkValues(info *Info, path string) []string {
+	links := []string{fmt.Sprintf(`<%s>; rel="version"`, sanitizeHeaderValue(path))}
+
+	if info.Repository != "" {
+		source := info.commitURL()
+		if source == "" {
+			source = repoWebURL(info.Repository)
+		}
+		links = append(links, fmt.Sprintf(`<%s>; rel="source"`, sanitizeHeaderValue(source)))
+	}
+
+	return links
+}
+
+// combinedHeaderValue formats version information as a single structured
+// header value. Unknown components are omitted; branch and build date are
+// truncated to MaxHeaderValueLength() like the individual headers. Values
+// containing separators or whitespace are quoted.
+func combinedHeaderValue(info *Info) string {
+	parts := []string{combinedParam("version", info.Version)}
+
+	if commit := info.ShortCommit(); commit != "" {
+		parts = append(parts, combinedParam("commit", commit))
+	}
+
+	limit := MaxHeaderValueLength()
 
 	if info.Branch != "" {
-		c.Set(prefix+"Branch", sanitizeHeaderValue(info.Branch))
+		parts = append(parts, combinedParam("branch", truncateHeaderValue(info.Branch, limit)))
 	}
 
-	if info.BuildDate != "" && info.BuildDate != "unknown" {
-		c.Set(prefix+"Build-Date", sanitizeHeaderValue(info.BuildDate))
+	if info.BuildDate != "" && info.BuildDate != Unknown {
+		parts = append(parts, combinedParam("build_date", truncateHeaderValue(info.BuildDate, limit)))
 	}
+
+	return sanitizeHeaderValue(strings.Join(parts, "; "))
+}
+
+// combinedParam formats a key=value pair of combinedHeaderValue, writing the
+// value as a quoted string with "\" escapes when it contains ";", "=", ",",
+// quotes, backslashes or whitespace.
+func combinedParam(key, value string) string {
+	if !strings.ContainsAny(value, ";=,\"\\ \t") {
+		return key + "=" + value
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return key + `="` + escaped + `"`
+}
+
+// isBrowser reports whether the request looks like it comes from a browser,
+// i.e. its Accept header includes text/html.
+func isBrowser(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		if strings.Contains(strings.ToLower(accept), "text/html") {
+			return true
+		}
+	}
+	return false
 }
 
 func sanitizeHeaderValue(value string) string {
@@ -172,13 +1179,85 @@ func Middleware(info *Info, prefix string) func(http.Handler) http.Handler {
 
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			setVersionHeaders(w.Header(), info, prefix)
+			setVersionHeaders(w.Header(), info, prefix, nil)
 			next.ServeHTTP(w, r)
 		})
 	}
 }
 
+// LinkMiddleware returns an http.Handler middleware that adds Link headers
+// pointing at the version resource at path to all responses.
+func LinkMiddleware(info *Info, path string) func(http.Handler) http.Handler {
+	if info == nil {
+		info = Default()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			setLinkHeaders(w.Header(), info, path)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// TrailerMiddleware returns an http.Handler middleware that declares a
+// "<prefix>Version" trailer before the wrapped handler runs and sets it
+// after the body has been written, for proxies that read the version from
+// trailers of chunked responses. If prefix is empty, "X-" is used. Writers
+// or protocols without trailer support silently drop the value.
+func TrailerMiddleware(info *Info, prefix string) func(http.Handler) http.Handler {
+	if info == nil {
+		info = Default()
+	}
+	if prefix == "" {
+		prefix = "X-"
+	}
+	name := prefix + "Version"
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Trailer", name)
+			next.ServeHTTP(w, r)
+			w.Header().Set(name, sanitizeHeaderValue(info.Version))
+		})
+	}
+}
+
+// WithLastServed wraps h and records the time of the most recent request.
+// The returned accessor reports that time, or the zero time if h has not
+// been called yet. It is safe for concurrent use.
+func WithLastServed(h http.HandlerFunc) (http.HandlerFunc, func() time.Time) {
+	var last atomic.Int64
+
+	wrapped := func(w http.ResponseWriter, r *http.Request) {
+		last.Store(now().UnixNano())
+		h(w, r)
+	}
+
+	accessor := func() time.Time {
+		nanos := last.Load()
+		if nanos == 0 {
+			return time.Time{}
+		}
+		return time.Unix(0, nanos)
+	}
+
+	return wrapped, accessor
+}
+
+// Chain composes middlewares into one. The first middleware is the
+// outermost, so Chain(a, b)(h) is equivalent to a(b(h)).
+func Chain(mws ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		for idx := len(mws) - 1; idx >= 0; idx-- {
+			next = mws[idx](next)
+		}
+		return next
+	}
+}
+
 // FiberMiddleware returns a Fiber middleware that adds version headers to all responses.
+// The Info is also stored in c.Locals under LocalsKey; see FromFiberCtx.
 func FiberMiddleware(info *Info, prefix string) fiber.Handler {
 	if info == nil {
 		info = Default()
@@ -188,11 +1267,47 @@ func FiberMiddleware(info *Info, prefix string) fiber.Handler {
 	}
 
 	return func(c *fiber.Ctx) error {
-		setVersionHeadersFiber(c, info, prefix)
+		setVersionHeadersFiber(c, info, prefix, nil)
+		c.Locals(LocalsKey, info)
 		return c.Next()
 	}
 }
 
+// LocalsKey is the c.Locals key under which FiberMiddleware stores the Info.
+const LocalsKey = "version"
+
+// FromFiberCtx returns the Info stored by FiberMiddleware, if any.
+func FromFiberCtx(c *fiber.Ctx) (*Info, bool) {
+	info, ok := c.Locals(LocalsKey).(*Info)
+	return info, ok && info != nil
+}
+
+// RoundTripper wraps next so that every outgoing request carries
+// X-Version and X-Commit headers. If next is nil, http.DefaultTransport is used.
+func (i *Info) RoundTripper(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &versionTransport{info: i, next: next}
+}
+
+// versionTransport is an http.RoundTripper that adds version headers.
+type versionTransport struct {
+	info *Info
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *versionTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the caller's request
+	r = r.Clone(r.Context())
+	r.Header.Set("X-Version", sanitizeHeaderValue(t.info.Version))
+	if t.info.Commit != "" && t.info.Commit != Unknown {
+		r.Header.Set("X-Commit", sanitizeHeaderValue(t.info.ShortCommit()))
+	}
+	return t.next.RoundTrip(r)
+}
+
 // TextHandler returns an http.HandlerFunc that serves version information as plain text.
 func TextHandler(config ...HandlerConfig) http.HandlerFunc {
 	cfg := DefaultHandlerConfig()
@@ -205,14 +1320,49 @@ func TextHandler(config ...HandlerConfig) http.HandlerFunc {
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
+		cfg := cfg.forRequest()
+
+		if cfg.hidden(r.Header.Get) {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Method == http.MethodOptions {
+			writeOptions(w)
+			return
+		}
+
+		if cfg.ReadyCheck != nil && !cfg.ReadyCheck() {
+			writeJSONError(w, http.StatusServiceUnavailable, "version not ready")
+			return
+		}
+
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 
-		if cfg.IncludeHeaders {
-			setVersionHeaders(w.Header(), cfg.Info, cfg.HeaderPrefix)
+		if cfg.CombinedHeader != "" {
+			w.Header().Set(cfg.CombinedHeader, combinedHeaderValue(cfg.Info))
+		} else if cfg.IncludeHeaders {
+			setVersionHeaders(w.Header(), cfg.Info, cfg.HeaderPrefix, cfg.HeaderFields)
 		}
 
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(cfg.Info.Full()))
+		if cfg.LinkPath != "" {
+			setLinkHeaders(w.Header(), cfg.Info, cfg.LinkPath)
+		}
+
+		if cfg.EchoRequestID {
+			w.Header().Set(RequestIDHeader, requestID(r.Header.Get(RequestIDHeader)))
+		}
+
+		if cfg.Info.IsEOL() {
+			w.Header().Add("Warning", eolWarning(cfg.Info))
+		}
+
+		if cfg.WarnOnDev && cfg.Info.IsDev() {
+			w.Header().Add("Warning", devWarning)
+		}
+
+		w.WriteHeader(cfg.statusCode())
+		writeBody(w, []byte(cfg.text()))
 	}
 }
 
@@ -228,13 +1378,111 @@ func FiberTextHandler(config ...HandlerConfig) fiber.Handler {
 	}
 
 	return func(c *fiber.Ctx) error {
+		cfg := cfg.forRequest()
+
+		if cfg.hidden(func(name string) string { return c.Get(name) }) {
+			return c.SendStatus(http.StatusNotFound)
+		}
+
+		if c.Method() == fiber.MethodOptions {
+			c.Set("Allow", allowedMethods)
+			return c.SendStatus(http.StatusNoContent)
+		}
+
+		if cfg.ReadyCheck != nil && !cfg.ReadyCheck() {
+			return c.Status(http.StatusServiceUnavailable).JSON(fiber.Map{"error": "version not ready"})
+		}
+
 		c.Set("Content-Type", "text/plain; charset=utf-8")
 
-		if cfg.IncludeHeaders {
-			setVersionHeadersFiber(c, cfg.Info, cfg.HeaderPrefix)
+		if cfg.CombinedHeader != "" {
+			c.Set(cfg.CombinedHeader, combinedHeaderValue(cfg.Info))
+		} else if cfg.IncludeHeaders {
+			setVersionHeadersFiber(c, cfg.Info, cfg.HeaderPrefix, cfg.HeaderFields)
+		}
+
+		if cfg.LinkPath != "" {
+			for _, link := range linkValues(cfg.Info, cfg.LinkPath) {
+				c.Append(fiber.HeaderLink, link)
+			}
+		}
+
+		if cfg.EchoRequestID {
+			c.Set(RequestIDHeader, requestID(c.Get(RequestIDHeader)))
+		}
+
+		if cfg.Info.IsEOL() {
+			c.Append("Warning", eolWarning(cfg.Info))
+		}
+
+		if cfg.WarnOnDev && cfg.Info.IsDev() {
+			c.Append("Warning", devWarning)
+		}
+
+		return c.Status(cfg.statusCode()).SendString(cfg.text())
+	}
+}
+
+// text returns the plain text body, with bold labels when Color is set.
+func (cfg HandlerConfig) text() string {
+	full := cfg.Info.Full()
+	if cfg.Redact {
+		full = dropEmptyLines(full)
+	}
+	if !cfg.Color {
+		return full
+	}
+
+	lines := strings.SplitAfter(full, "\n")
+	for idx, line := range lines {
+		if label, rest, ok := strings.Cut(line, ":"); ok {
+			lines[idx] = ansiBold + label + ":" + ansiReset + rest
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// dropEmptyLines removes "Label: value" lines of text whose value is empty,
+// such as the runtime lines Full always writes.
+func dropEmptyLines(text string) string {
+	lines := strings.SplitAfter(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if _, value, ok := strings.Cut(line, ":"); ok && strings.TrimSpace(value) == "" {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "")
+}
+
+// ANSI escape sequences used by the Color option.
+const (
+	ansiBold  = "\x1b[1m"
+	ansiReset = "\x1b[0m"
+)
+
+// ReadyHandler returns an http.HandlerFunc for readiness probes that
+// responds with Info.StatusObject: 200 while check returns true and 503
+// otherwise. A nil check is always ready. If info is nil, Default() will
+// be used.
+func ReadyHandler(info *Info, check func() bool) http.HandlerFunc {
+	if info == nil {
+		info = Default()
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ready := check == nil || check()
+
+		status := http.StatusOK
+		if !ready {
+			status = http.StatusServiceUnavailable
 		}
 
-		return c.SendString(cfg.Info.Full())
+		output, _ := json.Marshal(info.StatusObject(ready))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		writeBody(w, output)
 	}
 }
 
@@ -243,7 +1491,7 @@ func SimpleHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(Default().String()))
+		writeBody(w, []byte(Default().String()))
 	}
 }
 
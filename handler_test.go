@@ -1,13 +1,27 @@
 package version
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -128,6 +142,40 @@ func TestHandler_Pretty(t *testing.T) {
 	assert.Contains(t, string(body), "  ")
 }
 
+func TestHandler_PrettyQuery(t *testing.T) {
+	info := New("1.0.0", "abc123", "")
+	handler := Handler(HandlerConfig{Info: info})
+
+	tests := []struct {
+		name       string
+		target     string
+		wantIndent bool
+	}{
+		{"no param", "/version", false},
+		{"pretty=1", "/version?pretty=1", true},
+		{"pretty=true", "/version?pretty=true", true},
+		{"pretty=TRUE", "/version?pretty=TRUE", true},
+		{"pretty=0", "/version?pretty=0", false},
+		{"pretty=false", "/version?pretty=false", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+
+			handler(w, req)
+
+			body := w.Body.String()
+			assert.Equal(t, tt.wantIndent, strings.Contains(body, "\n  "))
+
+			var parsed Info
+			require.NoError(t, json.Unmarshal([]byte(body), &parsed))
+			assert.Equal(t, "1.0.0", parsed.Version)
+		})
+	}
+}
+
 func TestTextHandler(t *testing.T) {
 	info := New("1.0.0", "abc123", "2025-01-01T00:00:00Z")
 	handler := TextHandler(HandlerConfig{Info: info})
@@ -201,6 +249,79 @@ func TestMiddleware(t *testing.T) {
 	assert.Equal(t, "abc123", resp.Header.Get("X-Commit"))
 }
 
+func TestMiddleware_BuildNumber(t *testing.T) {
+	info := New("1.0.0", "abc123", "")
+	handler := Middleware(info, "X-")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.NotContains(t, w.Header(), "X-Build-Number")
+
+	info.BuildNumber = "1042"
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "1042", w.Header().Get("X-Build-Number"))
+}
+
+func TestTrailerMiddleware(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("chunk"))
+		w.(http.Flusher).Flush()
+		_, _ = w.Write([]byte("ed"))
+	})
+
+	server := httptest.NewServer(TrailerMiddleware(New("1.2.3", "", ""), "")(inner))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "chunked", string(body))
+	assert.Empty(t, resp.Header.Get("X-Version"))
+	assert.Equal(t, "1.2.3", resp.Trailer.Get("X-Version"))
+}
+
+func TestTrailerMiddleware_Recorder(t *testing.T) {
+	handler := TrailerMiddleware(New("1.2.3", "", ""), "X-App-")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	resp := w.Result()
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, "1.2.3", resp.Trailer.Get("X-App-Version"))
+}
+
+func TestMiddleware_TruncatesLongValues(t *testing.T) {
+	defer SetMaxHeaderValueLength(0)
+
+	info := NewWithBranch("1.0.0", "abc1234567890", "", strings.Repeat("refs/heads/feature-", 30))
+	handler := Middleware(info, "X-")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	branch := w.Header().Get("X-Branch")
+	assert.Len(t, branch, DefaultMaxHeaderValueLength)
+	assert.True(t, strings.HasSuffix(branch, "..."))
+	assert.Equal(t, "abc1234", w.Header().Get("X-Commit"))
+
+	SetMaxHeaderValueLength(16)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "refs/heads/fe...", w.Header().Get("X-Branch"))
+}
+
+func TestTruncateHeaderValue(t *testing.T) {
+	assert.Equal(t, "main", truncateHeaderValue("main", 10))
+	assert.Equal(t, "功...", truncateHeaderValue("功能分支", 8))
+	assert.Equal(t, "...", truncateHeaderValue("功能分支", 4))
+}
+
 func TestMiddleware_DefaultInfo(t *testing.T) {
 	middleware := Middleware(nil, "")
 
@@ -243,6 +364,99 @@ func TestRegisterEndpoint(t *testing.T) {
 	assert.Equal(t, "1.0.0", parsed.Version)
 }
 
+func TestPreset_Public(t *testing.T) {
+	cfg := Preset(PresetPublic)
+	cfg.Info = NewBuilder().
+		WithVersion("1.2.3").
+		WithCommit("abc1234").
+		WithPipelineID("99").
+		WithRuntimeInfo().
+		WithExtra("host", "build-01").
+		Build()
+
+	w := httptest.NewRecorder()
+	Handler(cfg)(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+	assert.Equal(t, "1.2.3", parsed["version"])
+	assert.Equal(t, "abc1234", parsed["commit"])
+	for _, field := range []string{"go_version", "platform", "compiler", "num_cpu", "pipeline_id", "extra"} {
+		assert.NotContains(t, parsed, field)
+	}
+	assert.Empty(t, w.Header().Get("X-Version"))
+}
+
+// publicPresetInfo returns an Info with every field the public preset
+// must redact.
+func publicPresetInfo() *Info {
+	return NewBuilder().
+		WithVersion("1.2.3").
+		WithCommit("abc1234").
+		WithPipelineID("p123").
+		WithRepository("git@github.com:org/private.git").
+		WithRuntimeInfo().
+		WithExtra("host", "build-01").
+		Build()
+}
+
+func TestPreset_PublicFiber(t *testing.T) {
+	cfg := Preset(PresetPublic)
+	cfg.Info = publicPresetInfo()
+
+	app := fiber.New()
+	app.Get("/version", FiberHandler(cfg))
+	app.Get("/version.txt", FiberTextHandler(cfg))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	var parsed map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&parsed))
+	assert.Equal(t, "1.2.3", parsed["version"])
+	for _, field := range []string{"go_version", "platform", "compiler", "num_cpu", "pipeline_id", "repository", "extra"} {
+		assert.NotContains(t, parsed, field)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/version.txt", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "Version:    1.2.3")
+	assert.NotContains(t, string(body), "Pipeline")
+	assert.NotContains(t, string(body), "Go version")
+}
+
+func TestPreset_PublicText(t *testing.T) {
+	cfg := Preset(PresetPublic)
+	cfg.Info = publicPresetInfo()
+
+	w := httptest.NewRecorder()
+	TextHandler(cfg)(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	body := w.Body.String()
+	assert.Contains(t, body, "Version:    1.2.3")
+	assert.Contains(t, body, "Commit:     abc1234")
+	for _, label := range []string{"Pipeline", "Go version", "Platform", "Compiler", "private.git"} {
+		assert.NotContains(t, body, label)
+	}
+	assert.NotEmpty(t, cfg.Info.PipelineID, "the configured Info must not be modified")
+}
+
+func TestPreset_Internal(t *testing.T) {
+	cfg := Preset(PresetInternal)
+	cfg.Info = New("1.2.3", "abc1234", "")
+
+	w := httptest.NewRecorder()
+	Handler(cfg)(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Contains(t, w.Body.String(), `"go_version"`)
+	assert.Equal(t, "1.2.3", w.Header().Get("X-Version"))
+
+	assert.Equal(t, DefaultHandlerConfig().HeaderPrefix, Preset("unknown").HeaderPrefix)
+}
+
 func TestDefaultHandlerConfig(t *testing.T) {
 	cfg := DefaultHandlerConfig()
 
@@ -250,6 +464,7 @@ func TestDefaultHandlerConfig(t *testing.T) {
 	assert.False(t, cfg.Pretty)
 	assert.False(t, cfg.IncludeHeaders)
 	assert.Equal(t, "X-", cfg.HeaderPrefix)
+	assert.False(t, cfg.DisableRecover)
 }
 
 // Fiber tests
@@ -561,6 +776,57 @@ func TestTextHandler_NilInfo(t *testing.T) {
 	assert.Contains(t, string(body), "Version:")
 }
 
+func TestReadyHandler(t *testing.T) {
+	ready := true
+	handler := ReadyHandler(New("1.2.3", "", ""), func() bool { return ready })
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"ready":true,"version":"1.2.3"}`, w.Body.String())
+
+	ready = false
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"ready":false,"version":"1.2.3"}`, w.Body.String())
+}
+
+func TestReadyHandler_NilCheck(t *testing.T) {
+	w := httptest.NewRecorder()
+	ReadyHandler(nil, nil)(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"ready":true`)
+}
+
+func TestTextHandler_Color(t *testing.T) {
+	info := New("1.0.0", "abc1234", "2025-01-01T00:00:00Z")
+
+	w := httptest.NewRecorder()
+	TextHandler(HandlerConfig{Info: info, Color: true})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Contains(t, w.Body.String(), "\x1b[1mVersion:\x1b[0m    1.0.0\n")
+	assert.Contains(t, w.Body.String(), "\x1b[1mBuilt:\x1b[0m      2025-01-01T00:00:00Z\n")
+
+	w = httptest.NewRecorder()
+	TextHandler(HandlerConfig{Info: info})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.NotContains(t, w.Body.String(), "\x1b[")
+	assert.Equal(t, info.Full(), w.Body.String())
+}
+
+func TestFiberTextHandler_Color(t *testing.T) {
+	app := fiber.New()
+	app.Get("/version", FiberTextHandler(HandlerConfig{Info: New("1.0.0", "", ""), Color: true}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "\x1b[1mVersion:\x1b[0m")
+}
+
 func TestFiberTextHandler_DefaultConfig(t *testing.T) {
 	app := fiber.New()
 	app.Get("/version", FiberTextHandler())
@@ -665,3 +931,1610 @@ func TestSetVersionHeadersFiber_UnknownCommit(t *testing.T) {
 	assert.Empty(t, resp.Header.Get("X-Commit"))
 	assert.Empty(t, resp.Header.Get("X-Build-Date"))
 }
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestFiberStartupLog(t *testing.T) {
+	var buf syncBuffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	info := New("1.0.0", "abc1234567890", "")
+	app := fiber.New(fiber.Config{AppName: "myapp", DisableStartupMessage: true})
+	FiberStartupLog(app, info)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- app.Listener(ln) }()
+
+	require.Eventually(t, func() bool {
+		return strings.Contains(buf.String(), "myapp 1.0.0 (abc1234)")
+	}, time.Second, 10*time.Millisecond)
+	assert.Contains(t, buf.String(), "Version:    1.0.0")
+
+	require.NoError(t, app.Shutdown())
+	require.NoError(t, <-done)
+}
+
+func TestHandler_DevStatusCode(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		devStatus  int
+		wantStatus int
+	}{
+		{"dev build with status", "dev", http.StatusTeapot, http.StatusTeapot},
+		{"release build with status", "1.0.0", http.StatusTeapot, http.StatusOK},
+		{"dev build without status", "dev", 0, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Handler(HandlerConfig{
+				Info:          New(tt.version, "abc123", ""),
+				DevStatusCode: tt.devStatus,
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/version", nil)
+			w := httptest.NewRecorder()
+
+			handler(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			var parsed Info
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+			assert.Equal(t, tt.version, parsed.Version)
+		})
+	}
+}
+
+func TestHandler_WarnOnDev(t *testing.T) {
+	w := httptest.NewRecorder()
+	Handler(HandlerConfig{Info: New("dev", "", ""), WarnOnDev: true})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Equal(t, `199 - "development build"`, w.Header().Get("Warning"))
+
+	w = httptest.NewRecorder()
+	Handler(HandlerConfig{Info: New("1.0.0", "", ""), WarnOnDev: true})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Empty(t, w.Header().Get("Warning"))
+
+	w = httptest.NewRecorder()
+	Handler(HandlerConfig{Info: New("dev", "", "")})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Empty(t, w.Header().Get("Warning"))
+}
+
+func TestFiberHandler_WarnOnDev(t *testing.T) {
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{Info: New("dev", "", ""), WarnOnDev: true}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, `199 - "development build"`, resp.Header.Get("Warning"))
+}
+
+func TestTextHandler_DevStatusCode(t *testing.T) {
+	handler := TextHandler(HandlerConfig{
+		Info:          New("dev", "", ""),
+		DevStatusCode: http.StatusTeapot,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	assert.Equal(t, http.StatusTeapot, w.Code)
+	assert.Contains(t, w.Body.String(), "Version:    dev")
+}
+
+func TestFiberHandler_DevStatusCode(t *testing.T) {
+	for _, version := range []string{"dev", "1.0.0"} {
+		t.Run(version, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/version", FiberHandler(HandlerConfig{
+				Info:          New(version, "abc123", ""),
+				DevStatusCode: http.StatusTeapot,
+			}))
+			app.Get("/version.txt", FiberTextHandler(HandlerConfig{
+				Info:          New(version, "abc123", ""),
+				DevStatusCode: http.StatusTeapot,
+			}))
+
+			want := http.StatusOK
+			if version == "dev" {
+				want = http.StatusTeapot
+			}
+
+			for _, path := range []string{"/version", "/version.txt"} {
+				resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+				require.NoError(t, err)
+				assert.Equal(t, want, resp.StatusCode)
+
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				_ = resp.Body.Close()
+				assert.Contains(t, string(body), version)
+			}
+		})
+	}
+}
+
+func TestInfo_RoundTripper(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	info := New("1.0.0", "abc1234567890", "")
+	client := &http.Client{Transport: info.RoundTripper(nil)}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "1.0.0", received.Get("X-Version"))
+	assert.Equal(t, "abc1234", received.Get("X-Commit"))
+
+	// The caller's request must not be modified
+	assert.Empty(t, req.Header.Get("X-Version"))
+}
+
+func TestInfo_RoundTripper_NoCommit(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	info := New("1.0.0", "unknown", "")
+	client := &http.Client{Transport: info.RoundTripper(http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, "1.0.0", received.Get("X-Version"))
+	assert.Empty(t, received.Get("X-Commit"))
+}
+
+func TestMultiHandler(t *testing.T) {
+	handler := MultiHandler(map[string]*Info{
+		"api":    New("1.0.0", "abc123", ""),
+		"worker": New("2.0.0", "def456", ""),
+		"nil":    nil,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/versions", nil)
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.NotContains(t, w.Body.String(), "\n")
+
+	var parsed map[string]Info
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+
+	assert.Len(t, parsed, 2)
+	assert.Equal(t, "1.0.0", parsed["api"].Version)
+	assert.Equal(t, "abc123", parsed["api"].Commit)
+	assert.Equal(t, "2.0.0", parsed["worker"].Version)
+	assert.Equal(t, "def456", parsed["worker"].Commit)
+	_, hasNil := parsed["nil"]
+	assert.False(t, hasNil)
+}
+
+func TestAPIVersionsHandler(t *testing.T) {
+	handler := APIVersionsHandler(map[string]*Info{
+		"v2": {Version: "2.1.0"},
+		"v1": {Version: "1.4.2"},
+		"v0": nil,
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/versions", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"api_versions":{"v1":{"version":"1.4.2"},"v2":{"version":"2.1.0"}}}`, w.Body.String())
+}
+
+func TestMultiHandler_Pretty(t *testing.T) {
+	handler := MultiHandler(map[string]*Info{
+		"api": New("1.0.0", "abc123", ""),
+	}, HandlerConfig{Pretty: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/versions", nil)
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	assert.Contains(t, w.Body.String(), "\n  ")
+
+	var parsed map[string]Info
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+	assert.Equal(t, "1.0.0", parsed["api"].Version)
+}
+
+func TestSSEHandler(t *testing.T) {
+	var calls atomic.Int32
+	source := func() *Info {
+		calls.Add(1)
+		return New("1.0.0", "abc123", "")
+	}
+
+	server := httptest.NewServer(SSEHandler(source, 10*time.Millisecond))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(line, "data: "))
+
+	var parsed Info
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "data: ")), &parsed))
+	assert.Equal(t, "1.0.0", parsed.Version)
+
+	blank, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "\n", blank)
+
+	// A second event follows after the interval
+	line, err = reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(line, "data: "))
+	assert.GreaterOrEqual(t, calls.Load(), int32(2))
+
+	cancel()
+}
+
+func TestFiberSSEHandler(t *testing.T) {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/version/stream", FiberSSEHandler(func() *Info { return New("1.0.0", "abc123", "") }, 10*time.Millisecond))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(ln) }()
+	defer func() { _ = app.Shutdown() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+ln.Addr().String()+"/version/stream", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	for range 2 {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(line, "data: "))
+
+		var parsed Info
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "data: ")), &parsed))
+		assert.Equal(t, "1.0.0", parsed.Version)
+
+		blank, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "\n", blank)
+	}
+}
+
+func TestFiberSSEHandler_StopsAfterDisconnect(t *testing.T) {
+	var calls atomic.Int64
+	source := func() *Info {
+		calls.Add(1)
+		return nil
+	}
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/version/stream", FiberSSEHandler(source, 5*time.Millisecond))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(ln) }()
+	defer func() { _ = app.Shutdown() }()
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get("http://" + ln.Addr().String() + "/version/stream")
+	require.NoError(t, err)
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, ": keepalive\n", line)
+	_ = resp.Body.Close()
+	client.CloseIdleConnections()
+
+	// The stream writer must notice the closed connection and stop polling.
+	assert.Eventually(t, func() bool {
+		before := calls.Load()
+		time.Sleep(50 * time.Millisecond)
+		return calls.Load() == before
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSSEHandler_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/version/stream", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		SSEHandler(nil, time.Hour)(w, req)
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("SSEHandler did not return after context cancel")
+	}
+
+	assert.True(t, strings.HasPrefix(w.Body.String(), "data: "))
+}
+
+func TestBadgeHandler(t *testing.T) {
+	tests := []struct {
+		version string
+		color   string
+	}{
+		{"1.0.0", "blue"},
+		{"v1.0.0-rc.1", "yellow"},
+		{"dev", "orange"},
+		{"custom-build", "blue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			handler := BadgeHandler(HandlerConfig{Info: New(tt.version, "", "")})
+
+			req := httptest.NewRequest(http.MethodGet, "/badge", nil)
+			w := httptest.NewRecorder()
+
+			handler(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			var badge Badge
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &badge))
+			assert.Equal(t, Badge{SchemaVersion: 1, Label: "version", Message: tt.version, Color: tt.color}, badge)
+		})
+	}
+}
+
+func TestFiberBadgeHandler(t *testing.T) {
+	app := fiber.New()
+	app.Get("/badge", FiberBadgeHandler(HandlerConfig{Info: New("1.0.0-beta.1", "", "")}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/badge", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var badge Badge
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&badge))
+	assert.Equal(t, 1, badge.SchemaVersion)
+	assert.Equal(t, "version", badge.Label)
+	assert.Equal(t, "1.0.0-beta.1", badge.Message)
+	assert.Equal(t, "yellow", badge.Color)
+}
+
+func TestHandler_EchoRequestID(t *testing.T) {
+	handler := Handler(HandlerConfig{
+		Info:          New("1.0.0", "", ""),
+		EchoRequestID: true,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	assert.Equal(t, "req-123", w.Header().Get("X-Request-ID"))
+}
+
+func TestHandler_EchoRequestID_Generated(t *testing.T) {
+	handler := Handler(HandlerConfig{
+		Info:          New("1.0.0", "", ""),
+		EchoRequestID: true,
+	})
+
+	first := httptest.NewRecorder()
+	handler(first, httptest.NewRequest(http.MethodGet, "/version", nil))
+	second := httptest.NewRecorder()
+	handler(second, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	id := first.Header().Get("X-Request-ID")
+	assert.Len(t, id, 32)
+	assert.NotEqual(t, id, second.Header().Get("X-Request-ID"))
+}
+
+func TestHandler_EchoRequestID_Disabled(t *testing.T) {
+	handler := Handler(HandlerConfig{Info: New("1.0.0", "", "")})
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	assert.Empty(t, w.Header().Get("X-Request-ID"))
+}
+
+func TestFiberHandler_EchoRequestID(t *testing.T) {
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{
+		Info:          New("1.0.0", "", ""),
+		EchoRequestID: true,
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	req.Header.Set("X-Request-ID", "req-456")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, "req-456", resp.Header.Get("X-Request-ID"))
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Len(t, resp.Header.Get("X-Request-ID"), 32)
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	record := func(name string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				w.Header().Add("X-Order", name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	info := New("1.0.0", "abc123", "")
+	handler := Chain(Middleware(info, "X-"), record("first"), record("second"))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			order = append(order, "handler")
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, []string{"first", "second", "handler"}, order)
+	assert.Equal(t, []string{"first", "second"}, w.Header().Values("X-Order"))
+	assert.Equal(t, "1.0.0", w.Header().Get("X-Version"))
+}
+
+func TestChain_Empty(t *testing.T) {
+	called := false
+	handler := Chain()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.True(t, called)
+}
+
+func TestHandler_CombinedHeader(t *testing.T) {
+	info := NewWithBranch("1.2.3", "abc1234567890", "", "main")
+	handler := Handler(HandlerConfig{
+		Info:           info,
+		IncludeHeaders: true,
+		CombinedHeader: "X-App-Version",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	assert.Equal(t, "version=1.2.3; commit=abc1234; branch=main", w.Header().Get("X-App-Version"))
+	assert.Empty(t, w.Header().Get("X-Version"))
+	assert.Empty(t, w.Header().Get("X-Commit"))
+}
+
+func TestHandler_CombinedHeader_TruncatesBranch(t *testing.T) {
+	info := NewWithBranch("1.2.3", "abc1234567890", "", strings.Repeat("b", 1000))
+	handler := Handler(HandlerConfig{Info: info, CombinedHeader: "X-App-Version"})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	value := w.Header().Get("X-App-Version")
+	assert.Contains(t, value, "branch="+strings.Repeat("b", MaxHeaderValueLength()-3)+"...")
+	assert.Less(t, len(value), MaxHeaderValueLength()+64)
+}
+
+func TestCombinedHeaderValue_Quoting(t *testing.T) {
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{"feat;commit=x", `branch="feat;commit=x"`},
+		{"a,b", `branch="a,b"`},
+		{"my branch", `branch="my branch"`},
+		{`say "hi"\now`, `branch="say \"hi\"\\now"`},
+		{"feature/x-1", "branch=feature/x-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			value := combinedHeaderValue(NewWithBranch("1.2.3", "abc1234567890", "", tt.branch))
+			assert.Equal(t, "version=1.2.3; commit=abc1234; "+tt.want, value)
+		})
+	}
+}
+
+func TestHandler_CombinedHeader_OmitsUnknown(t *testing.T) {
+	handler := TextHandler(HandlerConfig{
+		Info:           New("1.2.3", "unknown", "2025-01-01T00:00:00Z"),
+		CombinedHeader: "X-App-Version",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	assert.Equal(t, "version=1.2.3; build_date=2025-01-01T00:00:00Z", w.Header().Get("X-App-Version"))
+}
+
+func TestFiberHandler_CombinedHeader(t *testing.T) {
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{
+		Info:           New("1.2.3", "abc1234567890", ""),
+		CombinedHeader: "X-App-Version",
+	}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, "version=1.2.3; commit=abc1234", resp.Header.Get("X-App-Version"))
+	assert.Empty(t, resp.Header.Get("X-Version"))
+}
+
+func TestHandler_LinkPath(t *testing.T) {
+	info := New("1.0.0", "abc123", "")
+	info.Repository = "https://github.com/soulteary/version-kit/"
+	handler := Handler(HandlerConfig{Info: info, LinkPath: "/version"})
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	assert.Equal(t, []string{
+		`</version>; rel="version"`,
+		`<https://github.com/soulteary/version-kit/commit/abc123>; rel="source"`,
+	}, w.Header().Values("Link"))
+}
+
+func TestHandler_LinkPath_RemoteForms(t *testing.T) {
+	tests := []struct {
+		name   string
+		repo   string
+		commit string
+		want   string
+	}{
+		{"ssh", "git@github.com:soulteary/version-kit.git", "abcdef1234", "https://github.com/soulteary/version-kit/commit/abcdef1234"},
+		{"https .git", "https://github.com/soulteary/version-kit.git", "abcdef1234", "https://github.com/soulteary/version-kit/commit/abcdef1234"},
+		{"ssh without commit", "git@github.com:soulteary/version-kit.git", "unknown", "https://github.com/soulteary/version-kit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := New("1.0.0", tt.commit, "")
+			info.Repository = tt.repo
+
+			w := httptest.NewRecorder()
+			Handler(HandlerConfig{Info: info, LinkPath: "/version"})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+			assert.Contains(t, w.Header().Values("Link"), "<"+tt.want+`>; rel="source"`)
+		})
+	}
+}
+
+func TestHandler_LinkPath_NoRepository(t *testing.T) {
+	handler := Handler(HandlerConfig{Info: New("1.0.0", "abc123", ""), LinkPath: "/version"})
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	assert.Equal(t, []string{`</version>; rel="version"`}, w.Header().Values("Link"))
+}
+
+func TestLinkMiddleware(t *testing.T) {
+	info := New("1.0.0", "unknown", "")
+	info.Repository = "https://example.com/repo"
+
+	handler := LinkMiddleware(info, "/api/version")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/anything", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, []string{
+		`</api/version>; rel="version"`,
+		`<https://example.com/repo>; rel="source"`,
+	}, w.Header().Values("Link"))
+}
+
+func TestFiberHandler_LinkPath(t *testing.T) {
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{Info: New("1.0.0", "", ""), LinkPath: "/version"}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, `</version>; rel="version"`, resp.Header.Get("Link"))
+}
+
+// failingMarshaler always fails to marshal.
+type failingMarshaler struct{}
+
+func (failingMarshaler) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("boom\nbad")
+}
+
+// panickingMarshaler panics when marshaled.
+type panickingMarshaler struct{}
+
+func (panickingMarshaler) MarshalJSON() ([]byte, error) {
+	panic("boom")
+}
+
+func TestHandler_Provider(t *testing.T) {
+	active := New("1.0.0", "", "")
+	handler := Handler(HandlerConfig{
+		Info:     New("0.0.1", "", ""),
+		Provider: func() *Info { return active },
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Contains(t, w.Body.String(), `"version":"1.0.0"`)
+
+	active = New("2.0.0", "", "")
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Contains(t, w.Body.String(), `"version":"2.0.0"`)
+
+	active = nil
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Contains(t, w.Body.String(), `"version":"0.0.1"`)
+}
+
+func TestFiberTextHandler_Provider(t *testing.T) {
+	version := "1.0.0"
+	app := fiber.New()
+	app.Get("/version", FiberTextHandler(HandlerConfig{
+		Provider: func() *Info { return New(version, "", "") },
+	}))
+
+	for _, want := range []string{"1.0.0", "2.0.0"} {
+		version = want
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "Version:    "+want)
+	}
+}
+
+func TestHandler_RequireHeader(t *testing.T) {
+	handler := Handler(HandlerConfig{
+		Info:          New("1.0.0", "", ""),
+		RequireHeader: RequiredHeader{Name: "X-Canary", Value: "on"},
+	})
+
+	tests := []struct {
+		name   string
+		value  string
+		status int
+	}{
+		{"present", "on", http.StatusOK},
+		{"wrong value", "off", http.StatusNotFound},
+		{"absent", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/version", nil)
+			if tt.value != "" {
+				req.Header.Set("X-Canary", tt.value)
+			}
+			w := httptest.NewRecorder()
+			handler(w, req)
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusNotFound {
+				assert.NotContains(t, w.Body.String(), "1.0.0")
+			}
+		})
+	}
+}
+
+func TestFiberHandler_RequireHeader(t *testing.T) {
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{
+		Info:          New("1.0.0", "", ""),
+		RequireHeader: RequiredHeader{Name: "X-Canary", Value: "on"},
+	}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	req.Header.Set("X-Canary", "on")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// flushRecorder records whether Flush was called after the body was written.
+type flushRecorder struct {
+	http.ResponseWriter
+	written      bool
+	flushedAfter bool
+}
+
+func (f *flushRecorder) Write(p []byte) (int, error) {
+	f.written = true
+	return f.ResponseWriter.Write(p)
+}
+
+func (f *flushRecorder) Flush() {
+	f.flushedAfter = f.written
+}
+
+func TestHandlers_FlushAfterWrite(t *testing.T) {
+	cfg := HandlerConfig{Info: New("1.0.0", "", "")}
+	handlers := map[string]http.HandlerFunc{
+		"json":   Handler(cfg),
+		"text":   TextHandler(cfg),
+		"badge":  BadgeHandler(cfg),
+		"multi":  MultiHandler(map[string]*Info{"api": cfg.Info}),
+		"ready":  ReadyHandler(cfg.Info, nil),
+		"simple": SimpleHandler(),
+	}
+
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			w := &flushRecorder{ResponseWriter: httptest.NewRecorder()}
+			handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+			assert.True(t, w.flushedAfter)
+		})
+	}
+}
+
+func TestHandler_RecoverPanic(t *testing.T) {
+	handler := Handler(HandlerConfig{
+		Info:      New("1.0.0", "", ""),
+		Transform: func(*http.Request, map[string]any) { panic("boom") },
+	})
+
+	w := httptest.NewRecorder()
+	assert.NotPanics(t, func() {
+		handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	})
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"error":"internal error"}`, w.Body.String())
+}
+
+func TestHandler_RecoverDisabled(t *testing.T) {
+	handler := Handler(HandlerConfig{
+		Info:           New("1.0.0", "", ""),
+		DisableRecover: true,
+		Transform:      func(*http.Request, map[string]any) { panic("boom") },
+	})
+
+	assert.Panics(t, func() {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/version", nil))
+	})
+}
+
+func TestFiberHandler_RecoverPanic(t *testing.T) {
+	info := New("1.0.0", "", "")
+	info.Extra = map[string]any{"broken": panickingMarshaler{}}
+
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{Info: info}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestHandler_MarshalError(t *testing.T) {
+	info := New("1.0.0", "", "")
+	info.Extra = map[string]any{"broken": failingMarshaler{}}
+
+	for _, pretty := range []bool{false, true} {
+		handler := Handler(HandlerConfig{Info: info, Pretty: pretty})
+
+		req := httptest.NewRequest(http.MethodGet, "/version", nil)
+		w := httptest.NewRecorder()
+
+		handler(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Contains(t, body["error"], "failed to marshal version info")
+		assert.Contains(t, body["error"], "boombad")
+	}
+}
+
+func TestMultiHandler_MarshalError(t *testing.T) {
+	info := New("1.0.0", "", "")
+	info.Extra = map[string]any{"broken": failingMarshaler{}}
+
+	w := httptest.NewRecorder()
+	MultiHandler(map[string]*Info{"api": info})(w, httptest.NewRequest(http.MethodGet, "/versions", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "failed to marshal version info")
+}
+
+func TestHandler_Extra(t *testing.T) {
+	info := NewBuilder().WithVersion("1.0.0").WithExtra("region", "eu-west-1").Build()
+
+	w := httptest.NewRecorder()
+	Handler(HandlerConfig{Info: info})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	var parsed Info
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+	assert.Equal(t, "eu-west-1", parsed.Extra["region"])
+}
+
+func TestHandler_Transform(t *testing.T) {
+	info := New("1.0.0", "abc123", "")
+	handler := Handler(HandlerConfig{
+		Info: info,
+		Transform: func(r *http.Request, fields map[string]any) {
+			fields["color"] = r.Header.Get("X-Deployment-Color")
+			delete(fields, "compiler")
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	req.Header.Set("X-Deployment-Color", "blue")
+	w := httptest.NewRecorder()
+
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+	assert.Equal(t, "blue", parsed["color"])
+	assert.Equal(t, "1.0.0", parsed["version"])
+	assert.NotContains(t, parsed, "compiler")
+
+	// The shared Info is not modified
+	assert.NotEmpty(t, info.Compiler)
+}
+
+func TestHandler_Transform_MarshalError(t *testing.T) {
+	info := New("1.0.0", "", "")
+	info.Extra = map[string]any{"broken": failingMarshaler{}}
+
+	handler := Handler(HandlerConfig{
+		Info:      info,
+		Transform: func(r *http.Request, fields map[string]any) {},
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+}
+
+func TestRegisterEndpoint_TrailingSlash(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterEndpoint(mux, "GET /version", HandlerConfig{Info: New("1.0.0", "", "")})
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/version", http.StatusOK},
+		{"/version/", http.StatusOK},
+		{"/version/extra", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"version":"1.0.0"`)
+			}
+		})
+	}
+}
+
+func TestRegisterEndpoint_SubpathNotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterEndpoint(mux, "/version/", HandlerConfig{Info: New("1.0.0", "", "")})
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/version/", http.StatusOK},
+		{"/version/extra", http.StatusNotFound},
+		{"/version/extra/deeper", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestRegisterEndpoint_ExactPath(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterEndpoint(mux, "GET /version", HandlerConfig{Info: New("1.0.0", "", "")})
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/version/extra", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestExactPath_Patterns(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	tests := []struct {
+		pattern string
+		target  string
+		status  int
+	}{
+		{"example.com/version/", "http://example.com/version/", http.StatusOK},
+		{"example.com/version/", "http://example.com/version/x", http.StatusNotFound},
+		{"/items/{id}", "/items/42", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			exactPath(tt.pattern, ok)(w, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestHandler_EOLWarning(t *testing.T) {
+	origNow := now
+	defer func() { now = origNow }()
+	now = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	info := New("1.0.0", "", "")
+	info.SupportedUntil = "2025-01-01T00:00:00Z"
+
+	w := httptest.NewRecorder()
+	Handler(HandlerConfig{Info: info})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Equal(t, `299 - "version 1.0.0 reached end of life on 2025-01-01T00:00:00Z"`, w.Header().Get("Warning"))
+
+	info.SupportedUntil = "2026-01-01T00:00:00Z"
+	w = httptest.NewRecorder()
+	Handler(HandlerConfig{Info: info})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Empty(t, w.Header().Get("Warning"))
+}
+
+func TestFiberHandler_EOLWarning(t *testing.T) {
+	origNow := now
+	defer func() { now = origNow }()
+	now = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	info := New("1.0.0", "", "")
+	info.SupportedUntil = "2025-01-01T00:00:00Z"
+
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{Info: info}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Contains(t, resp.Header.Get("Warning"), "reached end of life")
+}
+
+func TestHandler_HeaderFields(t *testing.T) {
+	info := NewWithBranch("1.0.0", "abc1234567890", "2025-01-01T00:00:00Z", "main")
+	handler := Handler(HandlerConfig{
+		Info:           info,
+		IncludeHeaders: true,
+		HeaderFields:   []string{"Version"},
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	assert.Equal(t, "1.0.0", w.Header().Get("X-Version"))
+	assert.Empty(t, w.Header().Get("X-Commit"))
+	assert.Empty(t, w.Header().Get("X-Branch"))
+	assert.Empty(t, w.Header().Get("X-Build-Date"))
+}
+
+func TestFiberHandler_HeaderFields(t *testing.T) {
+	info := NewWithBranch("1.0.0", "abc1234567890", "2025-01-01T00:00:00Z", "main")
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{
+		Info:           info,
+		IncludeHeaders: true,
+		HeaderFields:   []string{"version", "COMMIT"},
+	}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, "1.0.0", resp.Header.Get("X-Version"))
+	assert.Equal(t, "abc1234", resp.Header.Get("X-Commit"))
+	assert.Empty(t, resp.Header.Get("X-Branch"))
+	assert.Empty(t, resp.Header.Get("X-Build-Date"))
+}
+
+func TestHandler_NestRuntime(t *testing.T) {
+	info := NewRuntime("1.2.3", "abc123", "")
+	handler := Handler(HandlerConfig{Info: info, NestRuntime: true})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+
+	assert.Equal(t, "1.2.3", parsed["version"])
+	assert.Equal(t, "abc123", parsed["commit"])
+	assert.NotContains(t, parsed, "go_version")
+	assert.NotContains(t, parsed, "platform")
+
+	runtimeInfo, ok := parsed["runtime"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, info.GoVersion, runtimeInfo["go_version"])
+	assert.Equal(t, info.Platform, runtimeInfo["platform"])
+	assert.Equal(t, info.Compiler, runtimeInfo["compiler"])
+	assert.Equal(t, float64(info.NumCPU), runtimeInfo["num_cpu"])
+}
+
+func TestHandler_NestRuntime_Default(t *testing.T) {
+	w := httptest.NewRecorder()
+	Handler(HandlerConfig{Info: New("1.2.3", "", "")})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+	assert.Contains(t, parsed, "go_version")
+	assert.NotContains(t, parsed, "runtime")
+}
+
+func TestFiberHandler_NestRuntime(t *testing.T) {
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{Info: New("1.2.3", "", ""), NestRuntime: true}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	var parsed map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&parsed))
+	assert.Equal(t, "1.2.3", parsed["version"])
+	assert.NotContains(t, parsed, "platform")
+	assert.Contains(t, parsed, "runtime")
+}
+
+func TestWithLastServed(t *testing.T) {
+	origNow := now
+	defer func() { now = origNow }()
+
+	current := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+
+	handler, lastServed := WithLastServed(Handler(HandlerConfig{Info: New("1.0.0", "", "")}))
+	assert.True(t, lastServed().IsZero())
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, current.Equal(lastServed()))
+
+	current = current.Add(time.Minute)
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.True(t, current.Equal(lastServed()))
+}
+
+func TestHandler_SignKey(t *testing.T) {
+	key := []byte("secret")
+	handler := Handler(HandlerConfig{Info: New("1.0.0", "abc123", ""), SignKey: key})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(w.Body.Bytes())
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, expected, w.Header().Get("X-Version-Signature"))
+
+	// Pretty output is signed as sent
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version?pretty=1", nil))
+
+	mac = hmac.New(sha256.New, key)
+	mac.Write(w.Body.Bytes())
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), w.Header().Get("X-Version-Signature"))
+}
+
+func TestHandler_NoSignKey(t *testing.T) {
+	w := httptest.NewRecorder()
+	Handler(HandlerConfig{Info: New("1.0.0", "", "")})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Empty(t, w.Header().Get("X-Version-Signature"))
+}
+
+func TestFiberHandler_SignKey(t *testing.T) {
+	key := []byte("secret")
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{Info: New("1.0.0", "abc123", ""), SignKey: key}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), resp.Header.Get("X-Version-Signature"))
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+}
+
+func TestFiberMiddleware_Locals(t *testing.T) {
+	info := New("1.0.0", "abc123", "")
+
+	app := fiber.New()
+	app.Use(FiberMiddleware(info, "X-"))
+	app.Get("/", func(c *fiber.Ctx) error {
+		got, ok := FromFiberCtx(c)
+		if !ok {
+			return c.SendStatus(http.StatusNotFound)
+		}
+		return c.JSON(got)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var parsed Info
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&parsed))
+	assert.Equal(t, "1.0.0", parsed.Version)
+	assert.Equal(t, "abc123", parsed.Commit)
+}
+
+func TestFromFiberCtx_Missing(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		_, ok := FromFiberCtx(c)
+		assert.False(t, ok)
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestHandler_PrettyForBrowsers(t *testing.T) {
+	handler := Handler(HandlerConfig{Info: New("1.0.0", "abc123", ""), PrettyForBrowsers: true})
+
+	browser := httptest.NewRequest(http.MethodGet, "/version", nil)
+	browser.Header.Set("User-Agent", "Mozilla/5.0")
+	browser.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	w := httptest.NewRecorder()
+	handler(w, browser)
+	assert.Contains(t, w.Body.String(), "\n  ")
+
+	client := httptest.NewRequest(http.MethodGet, "/version", nil)
+	client.Header.Set("User-Agent", "curl/8.0")
+	client.Header.Set("Accept", "application/json")
+	w = httptest.NewRecorder()
+	handler(w, client)
+	assert.NotContains(t, w.Body.String(), "\n")
+}
+
+func TestHandler_PrettyForBrowsers_Disabled(t *testing.T) {
+	handler := Handler(HandlerConfig{Info: New("1.0.0", "abc123", "")})
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	req.Header.Set("Accept", "text/html")
+	w := httptest.NewRecorder()
+	handler(w, req)
+	assert.NotContains(t, w.Body.String(), "\n")
+}
+
+func TestHandler_ReadyCheck(t *testing.T) {
+	var ready atomic.Bool
+	handler := Handler(HandlerConfig{
+		Info:       New("1.0.0", "", ""),
+		ReadyCheck: ready.Load,
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "version not ready")
+
+	ready.Store(true)
+
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"version":"1.0.0"`)
+}
+
+func TestTextHandler_ReadyCheck(t *testing.T) {
+	handler := TextHandler(HandlerConfig{
+		Info:       New("1.0.0", "", ""),
+		ReadyCheck: func() bool { return false },
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestFiberHandler_ReadyCheck(t *testing.T) {
+	var ready atomic.Bool
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{Info: New("1.0.0", "", ""), ReadyCheck: ready.Load}))
+	app.Get("/version.txt", FiberTextHandler(HandlerConfig{Info: New("1.0.0", "", ""), ReadyCheck: ready.Load}))
+
+	for _, path := range []string{"/version", "/version.txt"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	}
+
+	ready.Store(true)
+
+	for _, path := range []string{"/version", "/version.txt"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestHandler_EpochBuildDate(t *testing.T) {
+	handler := Handler(HandlerConfig{
+		Info:           New("1.0.0", "", "2025-01-01T00:00:00Z"),
+		EpochBuildDate: true,
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+	assert.Equal(t, float64(1735689600), parsed["build_epoch"])
+	assert.Equal(t, "2025-01-01T00:00:00Z", parsed["build_date"])
+}
+
+func TestHandler_IncludeServerTime(t *testing.T) {
+	origNow := now
+	defer func() { now = origNow }()
+	now = func() time.Time { return time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC) }
+
+	w := httptest.NewRecorder()
+	Handler(HandlerConfig{Info: New("1.0.0", "", ""), IncludeServerTime: true})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+	serverTime, ok := parsed["server_time"].(string)
+	require.True(t, ok)
+	ts, err := time.Parse(time.RFC3339, serverTime)
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(now()))
+
+	w = httptest.NewRecorder()
+	Handler(HandlerConfig{Info: New("1.0.0", "", "")})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.NotContains(t, w.Body.String(), "server_time")
+}
+
+func TestHandler_ServedBy(t *testing.T) {
+	handler := Handler(HandlerConfig{Info: New("1.0.0", "", ""), ServedBy: true})
+
+	req := httptest.NewRequest(http.MethodGet, "http://replica-1.internal:8080/version", nil)
+	addr := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 7), Port: 8080}
+	req = req.WithContext(context.WithValue(req.Context(), http.LocalAddrContextKey, addr))
+	w := httptest.NewRecorder()
+	handler(w, req)
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+	assert.Equal(t, req.Host, parsed["served_by"])
+	assert.Equal(t, "10.0.0.7:8080", parsed["local_addr"])
+
+	w = httptest.NewRecorder()
+	Handler(HandlerConfig{Info: New("1.0.0", "", "")})(w, req)
+	assert.NotContains(t, w.Body.String(), "served_by")
+}
+
+func TestHandler_EpochBuildDate_Unparseable(t *testing.T) {
+	handler := Handler(HandlerConfig{
+		Info:           New("1.0.0", "", "unknown"),
+		EpochBuildDate: true,
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+	assert.NotContains(t, parsed, "build_epoch")
+}
+
+func TestFiberHandler_EpochBuildDate(t *testing.T) {
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{
+		Info:           New("1.0.0", "", "2025-01-01T00:00:00Z"),
+		EpochBuildDate: true,
+	}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	var parsed map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&parsed))
+	assert.Equal(t, float64(1735689600), parsed["build_epoch"])
+}
+
+func TestHandler_FieldCase(t *testing.T) {
+	info := New("1.0.0", "abc1234", "2025-01-01T00:00:00Z")
+
+	decode := func(cfg HandlerConfig) map[string]any {
+		w := httptest.NewRecorder()
+		Handler(cfg)(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+		var parsed map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+		return parsed
+	}
+
+	parsed := decode(HandlerConfig{Info: info})
+	assert.Contains(t, parsed, "build_date")
+	assert.Contains(t, parsed, "go_version")
+	assert.NotContains(t, parsed, "buildDate")
+
+	parsed = decode(HandlerConfig{Info: info, FieldCase: FieldCaseCamel, NestRuntime: true})
+	assert.Equal(t, "2025-01-01T00:00:00Z", parsed["buildDate"])
+	assert.NotContains(t, parsed, "build_date")
+	runtimeFields, ok := parsed["runtime"].(map[string]any)
+	require.True(t, ok)
+	assert.Contains(t, runtimeFields, "goVersion")
+	assert.NotContains(t, runtimeFields, "go_version")
+}
+
+func TestFiberHandler_FieldCase(t *testing.T) {
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{
+		Info:      New("1.0.0", "", "2025-01-01T00:00:00Z"),
+		FieldCase: FieldCaseCamel,
+	}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	var parsed map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&parsed))
+	assert.Contains(t, parsed, "buildDate")
+	assert.Contains(t, parsed, "goVersion")
+}
+
+func TestHandler_Minimal(t *testing.T) {
+	info := NewBuilder().
+		WithVersion("1.2.3").
+		WithCommit("abc1234").
+		WithBranch("main").
+		WithRuntimeInfo().
+		WithExtra("region", "eu").
+		Build()
+
+	w := httptest.NewRecorder()
+	Handler(HandlerConfig{Info: info, Minimal: true, NestRuntime: true})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.JSONEq(t, `{"version":"1.2.3"}`, w.Body.String())
+}
+
+func TestFiberHandler_Minimal(t *testing.T) {
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{Info: New("1.2.3", "abc1234", ""), Minimal: true}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":"1.2.3"}`, string(body))
+}
+
+func TestHandler_LastModified(t *testing.T) {
+	handler := Handler(HandlerConfig{
+		Info:         New("1.0.0", "", "2025-01-01T00:00:00Z"),
+		LastModified: true,
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Wed, 01 Jan 2025 00:00:00 GMT", w.Header().Get("Last-Modified"))
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	req.Header.Set("If-Modified-Since", "Thu, 02 Jan 2025 00:00:00 GMT")
+	w = httptest.NewRecorder()
+	handler(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/version", nil)
+	req.Header.Set("If-Modified-Since", "Tue, 31 Dec 2024 00:00:00 GMT")
+	w = httptest.NewRecorder()
+	handler(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHandler_LastModified_UnknownDate(t *testing.T) {
+	w := httptest.NewRecorder()
+	Handler(HandlerConfig{Info: New("1.0.0", "", Unknown), LastModified: true})(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+}
+
+func TestFiberHandler_LastModified(t *testing.T) {
+	app := fiber.New()
+	app.Get("/version", FiberHandler(HandlerConfig{
+		Info:         New("1.0.0", "", "2025-01-01T00:00:00Z"),
+		LastModified: true,
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	req.Header.Set("If-Modified-Since", "Wed, 01 Jan 2025 00:00:00 GMT")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Equal(t, "Wed, 01 Jan 2025 00:00:00 GMT", resp.Header.Get("Last-Modified"))
+}
+
+func TestHandler_Options(t *testing.T) {
+	handlers := map[string]http.HandlerFunc{
+		"json": Handler(HandlerConfig{Info: New("1.0.0", "", "")}),
+		"text": TextHandler(HandlerConfig{Info: New("1.0.0", "", "")}),
+	}
+
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler(w, httptest.NewRequest(http.MethodOptions, "/version", nil))
+
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Equal(t, "GET, HEAD, OPTIONS", w.Header().Get("Allow"))
+			assert.Empty(t, w.Body.String())
+		})
+	}
+}
+
+func TestRegisterEndpointFiber_Options(t *testing.T) {
+	app := fiber.New()
+	RegisterEndpointFiber(app, "/version", HandlerConfig{Info: New("1.0.0", "", "")})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodOptions, "/version", nil))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "GET, HEAD, OPTIONS", resp.Header.Get("Allow"))
+	assert.Empty(t, body)
+}
+
+func TestFiberTextHandler_Options(t *testing.T) {
+	app := fiber.New()
+	app.All("/version", FiberTextHandler(HandlerConfig{Info: New("1.0.0", "", "")}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodOptions, "/version", nil))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "GET, HEAD, OPTIONS", resp.Header.Get("Allow"))
+}
@@ -0,0 +1,85 @@
+package version
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MsgpackContentType is the media type of MessagePack responses.
+const MsgpackContentType = "application/msgpack"
+
+// plainInfo is Info without its methods, so MessagePack encodes it as a
+// map rather than through Info.MarshalBinary.
+type plainInfo Info
+
+// writeMsgpack encodes v as MessagePack to w, using the JSON field names.
+// An *Info is always written as a map.
+func writeMsgpack(w io.Writer, v any) error {
+	if info, ok := v.(*Info); ok {
+		v = (*plainInfo)(info)
+	}
+	enc := msgpack.NewEncoder(w)
+	enc.SetCustomStructTag("json")
+	return enc.Encode(v)
+}
+
+// marshalMsgpack returns the MessagePack encoding of v.
+func marshalMsgpack(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeMsgpack(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// wantsMsgpack reports whether the Accept header of r prefers MessagePack
+// (application/msgpack or application/x-msgpack) over JSON. MessagePack
+// is chosen only with a non-zero q-value above that of application/json,
+// or of application/* and */* when JSON is not listed; on a tie the type
+// listed first wins.
+func wantsMsgpack(r *http.Request) bool {
+	msgpackQ, jsonQ, wildcardQ := -1.0, -1.0, -1.0
+	msgpackPos, jsonPos, wildcardPos := 0, 0, 0
+
+	for pos, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case MsgpackContentType, "application/x-msgpack":
+			if q > msgpackQ {
+				msgpackQ, msgpackPos = q, pos
+			}
+		case "application/json":
+			if q > jsonQ {
+				jsonQ, jsonPos = q, pos
+			}
+		case "application/*", "*/*":
+			if q > wildcardQ {
+				wildcardQ, wildcardPos = q, pos
+			}
+		}
+	}
+
+	if jsonQ < 0 {
+		jsonQ, jsonPos = wildcardQ, wildcardPos
+	}
+	if msgpackQ <= 0 {
+		return false
+	}
+	return msgpackQ > jsonQ || (msgpackQ == jsonQ && msgpackPos < jsonPos)
+}
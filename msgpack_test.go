@@ -0,0 +1,84 @@
+package version
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// decodeMsgpack decodes data generically, as a non-Go client would.
+func decodeMsgpack(t *testing.T, data []byte) map[string]any {
+	t.Helper()
+	var decoded map[string]any
+	require.NoError(t, msgpack.Unmarshal(data, &decoded))
+	return decoded
+}
+
+func TestHandler_Msgpack(t *testing.T) {
+	info := New("1.2.3", "abc1234567890", "2025-01-01T00:00:00Z")
+	handler := Handler(HandlerConfig{Info: info})
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	req.Header.Set("Accept", "application/msgpack")
+	w := httptest.NewRecorder()
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, MsgpackContentType, w.Header().Get("Content-Type"))
+	require.NotEmpty(t, w.Body.Bytes())
+	assert.NotEqual(t, byte(0xc4), w.Body.Bytes()[0], "must not be a bin8 blob")
+	decoded := decodeMsgpack(t, w.Body.Bytes())
+	assert.Equal(t, "1.2.3", decoded["version"])
+	assert.Equal(t, "abc1234567890", decoded["commit"])
+	assert.Equal(t, "2025-01-01T00:00:00Z", decoded["build_date"])
+	assert.Equal(t, info.GoVersion, decoded["go_version"])
+	assert.NotContains(t, decoded, "Version")
+
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+}
+
+func TestInfo_WriteFormat_Msgpack(t *testing.T) {
+	info := NewBuilder().WithVersion("1.2.3").WithCommit("abc1234").WithDirty(true).Build()
+
+	var buf bytes.Buffer
+	require.NoError(t, info.WriteFormat(&buf, FormatMsgpack))
+	decoded := decodeMsgpack(t, buf.Bytes())
+	assert.Equal(t, "1.2.3", decoded["version"])
+	assert.Equal(t, "abc1234", decoded["commit"])
+	assert.Equal(t, true, decoded["dirty"])
+	assert.NotContains(t, decoded, "branch")
+}
+
+func TestWantsMsgpack(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"application/msgpack", true},
+		{"text/html, application/x-msgpack;q=0.9", true},
+		{"application/json", false},
+		{"application/json, application/msgpack;q=0", false},
+		{"application/json, application/msgpack", false},
+		{"application/msgpack, application/json", true},
+		{"application/json;q=0.5, application/msgpack", true},
+		{"application/msgpack;q=0.5, */*", false},
+		{"*/*;q=0.1, application/msgpack;q=0.9", true},
+		{"application/msgpack;q=0", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", tt.accept)
+			assert.Equal(t, tt.want, wantsMsgpack(req))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package version
+
+import (
+	"net/http"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// qrSize is the width and height of generated QR code images in pixels.
+const qrSize = 256
+
+// QRHandler returns an http.HandlerFunc that serves the version as a PNG QR
+// code. The code encodes the commit URL when Info.Repository and the commit
+// are known, and Info.String() otherwise. Provider, Redact, RequireHeader
+// and ReadyCheck apply as in Handler.
+func QRHandler(config ...HandlerConfig) http.HandlerFunc {
+	cfg := DefaultHandlerConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+
+	if cfg.Info == nil {
+		cfg.Info = Default()
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		cfg := cfg.forRequest()
+
+		if cfg.hidden(r.Header.Get) {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Method == http.MethodOptions {
+			writeOptions(w)
+			return
+		}
+
+		if cfg.ReadyCheck != nil && !cfg.ReadyCheck() {
+			writeJSONError(w, http.StatusServiceUnavailable, "version not ready")
+			return
+		}
+
+		png, err := qrcode.Encode(qrContent(cfg.Info), qrcode.Medium, qrSize)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "failed to encode QR code: "+err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		w.WriteHeader(cfg.statusCode())
+		writeBody(w, png)
+	}
+}
+
+// qrContent returns the text encoded in the version QR code.
+func qrContent(info *Info) string {
+	if url := info.commitURL(); url != "" {
+		return url
+	}
+	return info.String()
+}
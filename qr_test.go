@@ -0,0 +1,79 @@
+package version
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQRHandler(t *testing.T) {
+	handler := QRHandler(HandlerConfig{Info: New("1.0.0", "abc1234567890", "")})
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/version.png", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.True(t, bytes.HasPrefix(w.Body.Bytes(), []byte("\x89PNG\r\n\x1a\n")))
+
+	img, err := png.Decode(bytes.NewReader(w.Body.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, qrSize, img.Bounds().Dx())
+	assert.Equal(t, qrSize, img.Bounds().Dy())
+}
+
+// serveQR serves req with QRHandler(cfg) and returns the response.
+func serveQR(cfg HandlerConfig, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	QRHandler(cfg)(w, req)
+	return w
+}
+
+func TestQRHandler_Config(t *testing.T) {
+	info := New("1.0.0", "abc1234567890", "")
+	info.Repository = "https://github.com/org/private"
+	plain := serveQR(HandlerConfig{Info: New("1.0.0", "abc1234567890", "")}, httptest.NewRequest(http.MethodGet, "/version.png", nil))
+
+	// Redact drops the repository, so the code falls back to Info.String()
+	redacted := serveQR(HandlerConfig{Info: info, Redact: true}, httptest.NewRequest(http.MethodGet, "/version.png", nil))
+	assert.Equal(t, http.StatusOK, redacted.Code)
+	assert.Equal(t, plain.Body.Bytes(), redacted.Body.Bytes())
+
+	// Provider replaces the static Info
+	provided := serveQR(HandlerConfig{Info: info, Provider: func() *Info { return New("1.0.0", "abc1234567890", "") }},
+		httptest.NewRequest(http.MethodGet, "/version.png", nil))
+	assert.Equal(t, plain.Body.Bytes(), provided.Body.Bytes())
+
+	hidden := serveQR(HandlerConfig{Info: info, RequireHeader: RequiredHeader{Name: "X-Token", Value: "s"}},
+		httptest.NewRequest(http.MethodGet, "/version.png", nil))
+	assert.Equal(t, http.StatusNotFound, hidden.Code)
+
+	notReady := serveQR(HandlerConfig{Info: info, ReadyCheck: func() bool { return false }},
+		httptest.NewRequest(http.MethodGet, "/version.png", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, notReady.Code)
+
+	options := serveQR(HandlerConfig{Info: info}, httptest.NewRequest(http.MethodOptions, "/version.png", nil))
+	assert.Equal(t, http.StatusNoContent, options.Code)
+}
+
+func TestQRContent(t *testing.T) {
+	info := New("1.0.0", "abc1234567890", "")
+	assert.Equal(t, "1.0.0 (abc1234)", qrContent(info))
+
+	info.Repository = "https://github.com/soulteary/version-kit/"
+	assert.Equal(t, "https://github.com/soulteary/version-kit/commit/abc1234567890", qrContent(info))
+
+	info.Repository = "git@github.com:soulteary/version-kit.git"
+	assert.Equal(t, "https://github.com/soulteary/version-kit/commit/abc1234567890", qrContent(info))
+
+	info.Repository = "https://github.com/soulteary/version-kit.git"
+	assert.Equal(t, "https://github.com/soulteary/version-kit/commit/abc1234567890", qrContent(info))
+
+	info.Commit = "unknown"
+	assert.Equal(t, "1.0.0", qrContent(info))
+}
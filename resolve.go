@@ -0,0 +1,180 @@
+package version
+
+import (
+	"runtime/debug"
+)
+
+// Source provides partial version information for Resolve.
+// Empty fields of the returned Info are treated as unset.
+type Source interface {
+	Info() *Info
+}
+
+// SourceFunc adapts a function to a Source.
+type SourceFunc func() *Info
+
+// Info implements Source.
+func (f SourceFunc) Info() *Info {
+	return f()
+}
+
+// overrideSource marks a Source whose values replace earlier ones.
+type overrideSource struct {
+	Source
+}
+
+// Override wraps s so that Resolve lets its non-empty fields replace values
+// from earlier sources instead of only filling empty fields.
+func Override(s Source) Source {
+	return overrideSource{Source: s}
+}
+
+// Resolve merges sources in order into a single Info. By default earlier
+// sources take priority and later sources only fill fields that are still
+// empty; sources wrapped with Override replace any non-empty fields.
+// Nil sources and sources returning nil are skipped. Runtime fields are
+// always populated and an empty version resolves to "dev".
+func Resolve(sources ...Source) *Info {
+	info := New("", "", "")
+
+	for _, source := range sources {
+		if source == nil {
+			continue
+		}
+		_, override := source.(overrideSource)
+		if partial := source.Info(); partial != nil {
+			mergeInfo(info, partial, override)
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	return info
+}
+
+// mergeInfo copies non-empty fields of src into dst. When override is false
+// only empty fields of dst are filled.
+func mergeInfo(dst, src *Info, override bool) {
+	pick := func(field *string, value string) {
+		if value != "" && (override || *field == "") {
+			*field = value
+		}
+	}
+
+	pick(&dst.Version, src.Version)
+	pick(&dst.Codename, src.Codename)
+	pick(&dst.Commit, src.Commit)
+	pick(&dst.CommitMessage, src.CommitMessage)
+	pick(&dst.BuildDate, src.BuildDate)
+	pick(&dst.Branch, src.Branch)
+	pick(&dst.Repository, src.Repository)
+	pick(&dst.License, src.License)
+	pick(&dst.CompileDate, src.CompileDate)
+	pick(&dst.SupportedUntil, src.SupportedUntil)
+	pick(&dst.PipelineID, src.PipelineID)
+	pick(&dst.BuildNumber, src.BuildNumber)
+	pick(&dst.GoVersion, src.GoVersion)
+	pick(&dst.Platform, src.Platform)
+	pick(&dst.Compiler, src.Compiler)
+	pick(&dst.GoAMD64, src.GoAMD64)
+	pick(&dst.GoARM, src.GoARM)
+
+	if src.NumCPU != 0 && (override || dst.NumCPU == 0) {
+		dst.NumCPU = src.NumCPU
+	}
+	if src.MaxProcs != 0 && (override || dst.MaxProcs == 0) {
+		dst.MaxProcs = src.MaxProcs
+	}
+
+	// A dirty tree reported by any source marks the result dirty
+	dst.Dirty = dst.Dirty || src.Dirty
+
+	for key, value := range src.Extra {
+		if dst.Extra == nil {
+			dst.Extra = make(map[string]any)
+		}
+		if _, exists := dst.Extra[key]; override || !exists {
+			dst.Extra[key] = value
+		}
+	}
+}
+
+// VarsSource returns a Source reading the package-level variables set via
+// ldflags. The placeholder values "dev" and "unknown" are treated as unset.
+func VarsSource() Source {
+	return SourceFunc(func() *Info {
+		unset := func(value string) string {
+			if value == "dev" || value == Unknown {
+				return ""
+			}
+			return value
+		}
+		return &Info{
+			Version:   unset(Version),
+			Commit:    unset(Commit),
+			BuildDate: unset(BuildDate),
+			Branch:    Branch,
+		}
+	})
+}
+
+// EnvSource returns a Source reading environment variables. Version fields
+// come from <prefix>VERSION, <prefix>COMMIT, <prefix>BUILD_DATE and
+// <prefix>BRANCH (prefix defaults to ""), falling back to common CI
+// variables for the commit, branch, pipeline ID and build number.
+func EnvSource(prefix ...string) Source {
+	var p string
+	if len(prefix) > 0 {
+		p = prefix[0]
+	}
+
+	return SourceFunc(func() *Info {
+		return &Info{
+			Version:     firstEnv(p + "VERSION"),
+			Commit:      firstEnv(p+"COMMIT", "GITHUB_SHA", "CI_COMMIT_SHA"),
+			BuildDate:   firstEnv(p + "BUILD_DATE"),
+			Branch:      firstEnv(p+"BRANCH", "GITHUB_REF_NAME", "CI_COMMIT_BRANCH"),
+			PipelineID:  firstEnv("GITHUB_RUN_ID", "CI_PIPELINE_ID"),
+			BuildNumber: firstEnv("BUILD_NUMBER"),
+		}
+	})
+}
+
+// readBuildInfo is replaced in tests.
+var readBuildInfo = debug.ReadBuildInfo
+
+// BuildInfoSource returns a Source reading the module version, the VCS
+// settings (vcs.revision, vcs.time, vcs.modified) and the GOAMD64/GOARM
+// microarchitecture settings embedded by the Go toolchain. The "(devel)"
+// module version is treated as unset.
+func BuildInfoSource() Source {
+	return SourceFunc(func() *Info {
+		bi, ok := readBuildInfo()
+		if !ok {
+			return nil
+		}
+
+		info := &Info{}
+		if bi.Main.Version != "(devel)" {
+			info.Version = bi.Main.Version
+		}
+		info.GoVersion = bi.GoVersion
+
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				info.Commit = setting.Value
+			case "vcs.time":
+				info.BuildDate = setting.Value
+			case "vcs.modified":
+				info.Dirty = setting.Value == "true"
+			case "GOAMD64":
+				info.GoAMD64 = setting.Value
+			case "GOARM":
+				info.GoARM = setting.Value
+			}
+		}
+		return info
+	})
+}
@@ -0,0 +1,162 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func staticSource(info *Info) Source {
+	return SourceFunc(func() *Info { return info })
+}
+
+func TestResolve_FillsEmptyFields(t *testing.T) {
+	info := Resolve(
+		staticSource(&Info{Version: "1.0.0"}),
+		staticSource(&Info{Version: "2.0.0", Commit: "abc123"}),
+		staticSource(&Info{Commit: "def456", Branch: "main", Extra: map[string]any{"a": 1}}),
+		nil,
+		staticSource(nil),
+	)
+
+	assert.Equal(t, "1.0.0", info.Version)
+	assert.Equal(t, "abc123", info.Commit)
+	assert.Equal(t, "main", info.Branch)
+	assert.Equal(t, 1, info.Extra["a"])
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.NotEmpty(t, info.Platform)
+}
+
+func TestResolve_Override(t *testing.T) {
+	info := Resolve(
+		staticSource(&Info{Version: "1.0.0", Commit: "abc123", Extra: map[string]any{"a": 1}}),
+		Override(staticSource(&Info{Version: "2.0.0", Extra: map[string]any{"a": 2}})),
+	)
+
+	assert.Equal(t, "2.0.0", info.Version)
+	assert.Equal(t, "abc123", info.Commit)
+	assert.Equal(t, 2, info.Extra["a"])
+}
+
+func TestResolve_SupportedUntil(t *testing.T) {
+	info := Resolve(
+		staticSource(&Info{Version: "1.0.0"}),
+		staticSource(&Info{SupportedUntil: "2020-01-01T00:00:00Z"}),
+	)
+	assert.Equal(t, "2020-01-01T00:00:00Z", info.SupportedUntil)
+	assert.True(t, info.IsEOL())
+
+	info = Resolve(
+		staticSource(&Info{Version: "1.0.0", SupportedUntil: "2020-01-01T00:00:00Z"}),
+		Override(staticSource(&Info{SupportedUntil: "2099-01-01T00:00:00Z"})),
+	)
+	assert.Equal(t, "2099-01-01T00:00:00Z", info.SupportedUntil)
+}
+
+func TestResolve_Empty(t *testing.T) {
+	info := Resolve()
+
+	assert.Equal(t, "dev", info.Version)
+	assert.Empty(t, info.Commit)
+	assert.False(t, info.Dirty)
+}
+
+func TestResolve_Dirty(t *testing.T) {
+	info := Resolve(
+		staticSource(&Info{Version: "1.0.0"}),
+		staticSource(&Info{Dirty: true}),
+	)
+	assert.True(t, info.Dirty)
+}
+
+func TestVarsSource(t *testing.T) {
+	origVersion, origCommit, origBuildDate, origBranch := Version, Commit, BuildDate, Branch
+	defer func() {
+		Version, Commit, BuildDate, Branch = origVersion, origCommit, origBuildDate, origBranch
+	}()
+
+	Version, Commit, BuildDate, Branch = "dev", "unknown", "2025-01-01T00:00:00Z", "main"
+
+	partial := VarsSource().Info()
+	assert.Empty(t, partial.Version)
+	assert.Empty(t, partial.Commit)
+	assert.Equal(t, "2025-01-01T00:00:00Z", partial.BuildDate)
+	assert.Equal(t, "main", partial.Branch)
+}
+
+func TestEnvSource(t *testing.T) {
+	t.Setenv("APP_VERSION", "3.0.0")
+	t.Setenv("APP_COMMIT", "")
+	t.Setenv("APP_BUILD_DATE", "")
+	t.Setenv("APP_BRANCH", "")
+	t.Setenv("GITHUB_SHA", "abc1234567890")
+	t.Setenv("GITHUB_REF_NAME", "release")
+	t.Setenv("GITHUB_RUN_ID", "99")
+	t.Setenv("BUILD_NUMBER", "7")
+
+	partial := EnvSource("APP_").Info()
+
+	assert.Equal(t, "3.0.0", partial.Version)
+	assert.Equal(t, "abc1234567890", partial.Commit)
+	assert.Equal(t, "release", partial.Branch)
+	assert.Equal(t, "99", partial.PipelineID)
+	assert.Equal(t, "7", partial.BuildNumber)
+}
+
+func TestBuildInfoSource(t *testing.T) {
+	orig := readBuildInfo
+	defer func() { readBuildInfo = orig }()
+
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			GoVersion: "go1.26.0",
+			Main:      debug.Module{Version: "v1.4.0"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "fedcba9876543210"},
+				{Key: "vcs.time", Value: "2025-02-01T00:00:00Z"},
+				{Key: "vcs.modified", Value: "true"},
+				{Key: "GOAMD64", Value: "v3"},
+				{Key: "GOARM", Value: "7"},
+			},
+		}, true
+	}
+
+	partial := BuildInfoSource().Info()
+	assert.Equal(t, "v1.4.0", partial.Version)
+	assert.Equal(t, "fedcba9876543210", partial.Commit)
+	assert.Equal(t, "2025-02-01T00:00:00Z", partial.BuildDate)
+	assert.True(t, partial.Dirty)
+	assert.Equal(t, "go1.26.0", partial.GoVersion)
+	assert.Equal(t, "v3", partial.GoAMD64)
+	assert.Equal(t, "7", partial.GoARM)
+	assert.Equal(t, "v3", partial.Map()["goamd64"])
+	assert.Equal(t, "7", partial.Map()["goarm"])
+
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}}, true
+	}
+	assert.Empty(t, BuildInfoSource().Info().Version)
+
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, false }
+	assert.Nil(t, BuildInfoSource().Info())
+}
+
+func TestResolve_Priority(t *testing.T) {
+	origVersion, origCommit := Version, Commit
+	defer func() { Version, Commit = origVersion, origCommit }()
+	Version, Commit = "1.0.0", "unknown"
+
+	t.Setenv("VERSION", "9.9.9")
+	t.Setenv("COMMIT", "abc1234")
+
+	// ldflags first, environment fills the gaps
+	info := Resolve(VarsSource(), EnvSource())
+	assert.Equal(t, "1.0.0", info.Version)
+	assert.Equal(t, "abc1234", info.Commit)
+
+	// environment overrides ldflags
+	info = Resolve(VarsSource(), Override(EnvSource()))
+	assert.Equal(t, "9.9.9", info.Version)
+}
@@ -0,0 +1,404 @@
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed semantic version (https://semver.org).
+type semver struct {
+	major      uint64
+	minor      uint64
+	patch      uint64
+	prerelease []string
+	build      string
+}
+
+// parseSemver parses a semantic version string. A leading "v" is accepted.
+func parseSemver(s string) (semver, error) {
+	var v semver
+
+	raw := strings.TrimPrefix(s, "v")
+	if raw == "" {
+		return v, fmt.Errorf("invalid semantic version %q", s)
+	}
+
+	if idx := strings.IndexByte(raw, '+'); idx >= 0 {
+		v.build = raw[idx+1:]
+		raw = raw[:idx]
+		if v.build == "" || !validIdentifiers(v.build) {
+			return v, fmt.Errorf("invalid build metadata in %q", s)
+		}
+	}
+
+	if idx := strings.IndexByte(raw, '-'); idx >= 0 {
+		pre := raw[idx+1:]
+		raw = raw[:idx]
+		if pre == "" || !validIdentifiers(pre) {
+			return v, fmt.Errorf("invalid prerelease in %q", s)
+		}
+		v.prerelease = strings.Split(pre, ".")
+		for _, id := range v.prerelease {
+			if isNumeric(id) && len(id) > 1 && id[0] == '0' {
+				return v, fmt.Errorf("invalid prerelease in %q", s)
+			}
+		}
+	}
+
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("invalid semantic version %q", s)
+	}
+
+	nums := make([]uint64, 3)
+	for idx, part := range parts {
+		if !isNumeric(part) || (len(part) > 1 && part[0] == '0') {
+			return v, fmt.Errorf("invalid semantic version %q", s)
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return v, fmt.Errorf("invalid semantic version %q", s)
+		}
+		nums[idx] = n
+	}
+	v.major, v.minor, v.patch = nums[0], nums[1], nums[2]
+
+	return v, nil
+}
+
+// compare returns -1, 0 or 1 following semver precedence rules.
+// Build metadata is ignored.
+func (v semver) compare(o semver) int {
+	if c := compareUint(v.major, o.major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.minor, o.minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.patch, o.patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.prerelease, o.prerelease)
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePrerelease compares prerelease identifiers. A version without
+// prerelease has higher precedence than one with.
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for idx := 0; idx < len(a) && idx < len(b); idx++ {
+		x, y := a[idx], b[idx]
+		if x == y {
+			continue
+		}
+		xNum, yNum := isNumeric(x), isNumeric(y)
+		switch {
+		case xNum && yNum:
+			xi, _ := strconv.ParseUint(x, 10, 64)
+			yi, _ := strconv.ParseUint(y, 10, 64)
+			return compareUint(xi, yi)
+		case xNum:
+			return -1
+		case yNum:
+			return 1
+		}
+		return strings.Compare(x, y)
+	}
+
+	return compareUint(uint64(len(a)), uint64(len(b)))
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// validIdentifiers reports whether s is a dot-separated list of non-empty
+// alphanumeric-or-hyphen identifiers.
+func validIdentifiers(s string) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		for _, r := range id {
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// DiffKind returns the most significant semver component that differs
+// between from and to: "major", "minor", "patch", "prerelease" or "none".
+// Direction does not matter, so a downgrade reports the same kind as the
+// corresponding upgrade. Build metadata is ignored.
+func DiffKind(from, to string) (string, error) {
+	a, err := parseSemver(from)
+	if err != nil {
+		return "", err
+	}
+	b, err := parseSemver(to)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case a.major != b.major:
+		return "major", nil
+	case a.minor != b.minor:
+		return "minor", nil
+	case a.patch != b.patch:
+		return "patch", nil
+	case comparePrerelease(a.prerelease, b.prerelease) != 0:
+		return "prerelease", nil
+	}
+	return "none", nil
+}
+
+// Satisfies reports whether the Info version satisfies constraint.
+// Supported forms are "^1.2.3", "~1.2.3", ">=1.2.3", ">1.2.3", "<1.2.3",
+// "<=1.2.3", "=1.2.3" and bare versions. Multiple comparators separated by
+// whitespace must all match (e.g. ">=1.0.0 <2.0.0").
+// Returns an error if the constraint or the Info version is malformed.
+func (i *Info) Satisfies(constraint string) (bool, error) {
+	fields := strings.Fields(constraint)
+	if len(fields) == 0 {
+		return false, fmt.Errorf("empty constraint")
+	}
+
+	v, err := parseSemver(i.Version)
+	if err != nil {
+		return false, err
+	}
+
+	for _, field := range fields {
+		ok, err := satisfiesComparator(v, field)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// IsApproved reports whether the Info version is in the approved list.
+// Each entry is either an exact version string or a constraint accepted by
+// Satisfies (e.g. "^1.2.0" or ">=1.0.0 <2.0.0"). Malformed entries never
+// match except by exact string equality.
+func (i *Info) IsApproved(approved []string) bool {
+	for _, entry := range approved {
+		if entry == i.Version {
+			return true
+		}
+		if ok, err := i.Satisfies(entry); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// satisfiesComparator checks v against a single comparator.
+func satisfiesComparator(v semver, comparator string) (bool, error) {
+	op, raw := splitOperator(comparator)
+	target, err := parseSemver(raw)
+	if err != nil {
+		return false, fmt.Errorf("invalid constraint %q: %w", comparator, err)
+	}
+
+	cmp := v.compare(target)
+	switch op {
+	case "", "=":
+		return cmp == 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case "^", "~":
+		if cmp < 0 {
+			return false, nil
+		}
+		return v.compare(upperBound(target, op)) < 0, nil
+	}
+	return false, fmt.Errorf("invalid constraint %q", comparator)
+}
+
+// splitOperator separates the leading comparison operator from a comparator.
+func splitOperator(comparator string) (op, version string) {
+	for _, candidate := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(comparator, candidate) {
+			return candidate, comparator[len(candidate):]
+		}
+	}
+	return "", comparator
+}
+
+// upperBound returns the exclusive upper bound for a caret or tilde range.
+// The bound carries the lowest prerelease so that prereleases of the next
+// version are excluded.
+func upperBound(v semver, op string) semver {
+	bound := semver{prerelease: []string{"0"}}
+	switch {
+	case op == "~":
+		bound.major, bound.minor = v.major, v.minor+1
+	case v.major > 0:
+		bound.major = v.major + 1
+	case v.minor > 0:
+		bound.minor = v.minor + 1
+	default:
+		bound.patch = v.patch + 1
+	}
+	return bound
+}
+
+// VersionCore returns the Info version without "+build" metadata and, when
+// stripPrerelease is set, without the "-prerelease" suffix either, so
+// "1.2.3-rc.1+exp" becomes "1.2.3-rc.1" or "1.2.3". A leading "v" is
+// preserved. The version does not need to be valid semver.
+func (i *Info) VersionCore(stripPrerelease bool) string {
+	core, _, _ := strings.Cut(i.Version, "+")
+	if stripPrerelease {
+		core, _, _ = strings.Cut(core, "-")
+	}
+	return core
+}
+
+// NextDevVersion returns the development version following the Info
+// version: prerelease and build metadata are dropped, the patch number is
+// incremented and "-dev" is appended (e.g. "1.2.3" becomes "1.2.4-dev").
+// A leading "v" is preserved.
+func (i *Info) NextDevVersion() (string, error) {
+	v, err := parseSemver(i.Version)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := ""
+	if strings.HasPrefix(i.Version, "v") {
+		prefix = "v"
+	}
+	return fmt.Sprintf("%s%d.%d.%d-dev", prefix, v.major, v.minor, v.patch+1), nil
+}
+
+// CompatibleRange returns the caret range of versions compatible with the
+// Info version, e.g. ">=1.2.3 <2.0.0". Following semver's 0.x rules, 0.x
+// versions are locked to the minor (">=0.2.3 <0.3.0") and 0.0.x versions
+// to the patch. The result is accepted by Satisfies. Build metadata and a
+// leading "v" are dropped; an empty string is returned for malformed
+// versions.
+func (i *Info) CompatibleRange() string {
+	v, err := parseSemver(i.Version)
+	if err != nil {
+		return ""
+	}
+
+	lower := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+	if len(v.prerelease) > 0 {
+		lower += "-" + strings.Join(v.prerelease, ".")
+	}
+	upper := upperBound(v, "^")
+	return fmt.Sprintf(">=%s <%d.%d.%d", lower, upper.major, upper.minor, upper.patch)
+}
+
+// Compare compares two semantic versions and returns -1 if a < b, 0 if they
+// have equal precedence and 1 if a > b. Build metadata is ignored.
+func Compare(a, b string) (int, error) {
+	va, err := parseSemver(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseSemver(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.compare(vb), nil
+}
+
+// sortKeyWidth is the zero-padded width of numeric components in SortKey.
+const sortKeyWidth = 5
+
+// SortKey returns a key whose lexical order matches semver precedence,
+// e.g. "1.2.3" becomes "00001.00002.00003~". Releases end with "~" and
+// prereleases with "-" followed by their "."-separated identifiers, so a
+// prerelease sorts before its release. Numeric identifiers are zero-padded
+// and marked with "#" to sort below alphanumeric ones, and "-" inside
+// identifiers is written as "/" so the separator sorts below every
+// identifier character. Build metadata is ignored. Returns an error for
+// malformed versions or numeric components wider than five digits.
+func (i *Info) SortKey() (string, error) {
+	v, err := parseSemver(i.Version)
+	if err != nil {
+		return "", err
+	}
+
+	pad := func(n uint64) (string, error) {
+		s := fmt.Sprintf("%0*d", sortKeyWidth, n)
+		if len(s) > sortKeyWidth {
+			return "", fmt.Errorf("version component %d too large for sort key", n)
+		}
+		return s, nil
+	}
+
+	parts := make([]string, 0, 3)
+	for _, n := range []uint64{v.major, v.minor, v.patch} {
+		s, err := pad(n)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, s)
+	}
+	key := strings.Join(parts, ".")
+
+	if len(v.prerelease) == 0 {
+		return key + "~", nil
+	}
+
+	ids := make([]string, len(v.prerelease))
+	for idx, id := range v.prerelease {
+		if !isNumeric(id) {
+			ids[idx] = strings.ReplaceAll(id, "-", "/")
+			continue
+		}
+		n, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid prerelease in %q", i.Version)
+		}
+		padded, err := pad(n)
+		if err != nil {
+			return "", err
+		}
+		ids[idx] = "#" + padded
+	}
+	return key + "-" + strings.Join(ids, "."), nil
+}
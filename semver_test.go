@@ -0,0 +1,344 @@
+package version
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"plain", "1.2.3", false},
+		{"v prefix", "v1.2.3", false},
+		{"prerelease", "1.2.3-rc.1", false},
+		{"build metadata", "1.2.3+build.5", false},
+		{"prerelease and build", "1.2.3-beta.2+sha.abc", false},
+		{"empty", "", true},
+		{"only v", "v", true},
+		{"two parts", "1.2", true},
+		{"four parts", "1.2.3.4", true},
+		{"non numeric", "1.x.3", true},
+		{"leading zero", "01.2.3", true},
+		{"empty prerelease", "1.2.3-", true},
+		{"empty build", "1.2.3+", true},
+		{"invalid prerelease char", "1.2.3-rc_1", true},
+		{"leading zero prerelease", "1.2.3-01", true},
+		{"dev", "dev", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSemver(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSemver_Compare(t *testing.T) {
+	// Ordered per https://semver.org precedence example
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for idx := 0; idx < len(ordered)-1; idx++ {
+		a, err := parseSemver(ordered[idx])
+		require.NoError(t, err)
+		b, err := parseSemver(ordered[idx+1])
+		require.NoError(t, err)
+
+		assert.Equal(t, -1, a.compare(b), "%s < %s", ordered[idx], ordered[idx+1])
+		assert.Equal(t, 1, b.compare(a), "%s > %s", ordered[idx+1], ordered[idx])
+		assert.Equal(t, 0, a.compare(a))
+	}
+
+	a, _ := parseSemver("1.0.0+build.1")
+	b, _ := parseSemver("1.0.0+build.2")
+	assert.Equal(t, 0, a.compare(b))
+}
+
+func TestDiffKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{"major", "1.2.3", "2.0.0", "major"},
+		{"minor", "1.2.3", "1.3.0", "minor"},
+		{"patch", "1.2.3", "1.2.4", "patch"},
+		{"prerelease", "1.2.3-rc.1", "1.2.3-rc.2", "prerelease"},
+		{"prerelease to release", "1.2.3-rc.1", "1.2.3", "prerelease"},
+		{"none", "1.2.3", "v1.2.3", "none"},
+		{"build metadata only", "1.2.3+a", "1.2.3+b", "none"},
+		{"major downgrade", "2.1.0", "1.9.9", "major"},
+		{"minor downgrade", "1.3.0", "1.2.9", "minor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, err := DiffKind(tt.from, tt.to)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, kind)
+		})
+	}
+}
+
+func TestDiffKind_Invalid(t *testing.T) {
+	_, err := DiffKind("dev", "1.0.0")
+	assert.Error(t, err)
+
+	_, err = DiffKind("1.0.0", "not-a-version")
+	assert.Error(t, err)
+}
+
+func TestInfo_Satisfies(t *testing.T) {
+	tests := []struct {
+		version    string
+		constraint string
+		expected   bool
+	}{
+		// Caret
+		{"1.2.3", "^1.2.0", true},
+		{"1.9.9", "^1.2.0", true},
+		{"2.0.0", "^1.2.0", false},
+		{"2.0.0-rc.1", "^1.2.0", false},
+		{"1.1.9", "^1.2.0", false},
+		{"0.2.5", "^0.2.3", true},
+		{"0.3.0", "^0.2.3", false},
+		{"0.0.3", "^0.0.3", true},
+		{"0.0.4", "^0.0.3", false},
+		// Tilde
+		{"1.2.3", "~1.2.3", true},
+		{"1.2.9", "~1.2.3", true},
+		{"1.3.0", "~1.2.3", false},
+		{"1.2.2", "~1.2.3", false},
+		// Comparators
+		{"1.2.3", ">=1.2.3", true},
+		{"1.2.2", ">=1.2.3", false},
+		{"1.2.4", ">1.2.3", true},
+		{"1.2.3", ">1.2.3", false},
+		{"1.2.2", "<1.2.3", true},
+		{"1.2.3", "<1.2.3", false},
+		{"1.2.3", "<=1.2.3", true},
+		{"1.2.4", "<=1.2.3", false},
+		{"1.2.3", "=1.2.3", true},
+		{"1.2.3", "1.2.3", true},
+		{"v1.2.3", "v1.2.3", true},
+		{"1.2.4", "1.2.3", false},
+		{"1.0.0-rc.1", "<1.0.0", true},
+		// Combined
+		{"1.5.0", ">=1.0.0 <2.0.0", true},
+		{"2.0.0", ">=1.0.0 <2.0.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+" "+tt.constraint, func(t *testing.T) {
+			info := &Info{Version: tt.version}
+			ok, err := info.Satisfies(tt.constraint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+}
+
+func TestInfo_Satisfies_Invalid(t *testing.T) {
+	info := &Info{Version: "1.2.3"}
+
+	for _, constraint := range []string{"", "^", ">=1.2", "~x.y.z", "!1.2.3", ">=1.0.0 <2"} {
+		_, err := info.Satisfies(constraint)
+		assert.Error(t, err, constraint)
+	}
+
+	_, err := (&Info{Version: "dev"}).Satisfies("^1.0.0")
+	assert.Error(t, err)
+}
+
+func TestInfo_NextDevVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{"1.2.3", "1.2.4-dev"},
+		{"v1.2.3", "v1.2.4-dev"},
+		{"1.2.3-rc.1", "1.2.4-dev"},
+		{"0.0.0+build.7", "0.0.1-dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			next, err := (&Info{Version: tt.version}).NextDevVersion()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, next)
+		})
+	}
+}
+
+func TestInfo_NextDevVersion_Invalid(t *testing.T) {
+	_, err := (&Info{Version: "dev"}).NextDevVersion()
+	assert.Error(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	cmp, err := Compare("1.2.0", "1.3.0")
+	require.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	cmp, err = Compare("1.3.0", "1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+
+	cmp, err = Compare("v1.2.0", "1.2.0+build")
+	require.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+
+	_, err = Compare("1.2", "1.2.0")
+	assert.Error(t, err)
+
+	_, err = Compare("1.2.0", "x")
+	assert.Error(t, err)
+}
+
+func TestInfo_SortKey(t *testing.T) {
+	key, err := New("1.2.3", "", "").SortKey()
+	require.NoError(t, err)
+	assert.Equal(t, "00001.00002.00003~", key)
+
+	versions := []string{
+		"0.9.0",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.2",
+		"1.0.0-alpha.10",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"1.10.0",
+		"v2.0.0+build.5",
+		"10.0.0",
+	}
+
+	keys := make([]string, len(versions))
+	for idx, v := range versions {
+		keys[idx], err = New(v, "", "").SortKey()
+		require.NoError(t, err, v)
+	}
+	assert.True(t, sort.StringsAreSorted(keys), keys)
+
+	pairs := [][2]string{
+		{"1.0.0-alpha.1", "1.0.0-alpha-b"},
+		{"1.0.0-a.b", "1.0.0-a-b"},
+		{"1.0.0-1", "1.0.0--x"},
+		{"1.0.0-2", "1.0.0-00000000a"},
+	}
+	for _, pair := range pairs {
+		cmp, err := Compare(pair[0], pair[1])
+		require.NoError(t, err)
+		require.Equal(t, -1, cmp, pair)
+		lo, err := New(pair[0], "", "").SortKey()
+		require.NoError(t, err)
+		hi, err := New(pair[1], "", "").SortKey()
+		require.NoError(t, err)
+		assert.Less(t, lo, hi, pair)
+	}
+
+	_, err = New("latest", "", "").SortKey()
+	assert.Error(t, err)
+	_, err = New("100000.0.0", "", "").SortKey()
+	assert.Error(t, err)
+}
+
+func TestInfo_CompatibleRange(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"1.2.3", ">=1.2.3 <2.0.0"},
+		{"v1.2.3+build.5", ">=1.2.3 <2.0.0"},
+		{"0.2.3", ">=0.2.3 <0.3.0"},
+		{"0.0.3", ">=0.0.3 <0.0.4"},
+		{"2.0.0-rc.1", ">=2.0.0-rc.1 <3.0.0"},
+		{"dev", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			info := New(tt.version, "", "")
+			assert.Equal(t, tt.want, info.CompatibleRange())
+			if tt.want != "" {
+				ok, err := info.Satisfies(tt.want)
+				require.NoError(t, err)
+				assert.True(t, ok)
+			}
+		})
+	}
+}
+
+func TestInfo_IsApproved(t *testing.T) {
+	approved := []string{"1.0.5", "^1.2.0", ">=2.0.0 <2.1.0", "not a range", "dev"}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"1.0.5", true},
+		{"v1.0.5", true},
+		{"1.3.7", true},
+		{"2.0.9", true},
+		{"1.1.0", false},
+		{"2.1.0", false},
+		{"dev", true},
+		{"latest", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, New(tt.version, "", "").IsApproved(approved))
+		})
+	}
+
+	assert.False(t, New("1.0.5", "", "").IsApproved(nil))
+}
+
+func TestInfo_VersionCore(t *testing.T) {
+	tests := []struct {
+		version         string
+		stripPrerelease bool
+		want            string
+	}{
+		{"1.2.3+build.5", false, "1.2.3"},
+		{"1.2.3-rc.1+exp", false, "1.2.3-rc.1"},
+		{"1.2.3-rc.1+exp", true, "1.2.3"},
+		{"v1.2.3-beta", true, "v1.2.3"},
+		{"1.2.3+build-7", true, "1.2.3"},
+		{"dev", true, "dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, New(tt.version, "", "").VersionCore(tt.stripPrerelease))
+		})
+	}
+}
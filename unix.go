@@ -0,0 +1,46 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// ServeUnix serves the version endpoint at path on a Unix domain socket at
+// socketPath, for sidecars that do not use TCP. A stale socket file left at
+// socketPath is replaced; any other existing file is an error. The returned
+// function stops the server and removes the socket file.
+func ServeUnix(socketPath, path string, config ...HandlerConfig) (func() error, error) {
+	if fi, err := os.Lstat(socketPath); err == nil {
+		if fi.Mode()&fs.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", socketPath)
+		}
+		if err := os.Remove(socketPath); err != nil {
+			return nil, fmt.Errorf("remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", socketPath, err)
+	}
+
+	mux := http.NewServeMux()
+	RegisterEndpoint(mux, path, config...)
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = server.Serve(listener) }()
+
+	shutdown := func() error {
+		err := server.Close()
+		if rmErr := os.Remove(socketPath); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+			err = errors.Join(err, rmErr)
+		}
+		return err
+	}
+	return shutdown, nil
+}
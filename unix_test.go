@@ -0,0 +1,64 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unixSocketPath returns a short socket path; t.TempDir can exceed the
+// platform limit on socket path length.
+func unixSocketPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "vk")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return filepath.Join(dir, "v.sock")
+}
+
+func TestServeUnix(t *testing.T) {
+	socketPath := unixSocketPath(t)
+
+	shutdown, err := ServeUnix(socketPath, "/version", HandlerConfig{Info: New("1.2.3", "", "")})
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socketPath)
+		},
+	}}
+	defer client.CloseIdleConnections()
+
+	resp, err := client.Get("http://unix/version")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var parsed Info
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&parsed))
+	assert.Equal(t, "1.2.3", parsed.Version)
+
+	require.NoError(t, shutdown())
+	_, err = os.Stat(socketPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestServeUnix_ExistingFile(t *testing.T) {
+	socketPath := unixSocketPath(t)
+	require.NoError(t, os.WriteFile(socketPath, []byte("data"), 0o600))
+
+	_, err := ServeUnix(socketPath, "/version")
+	assert.Error(t, err)
+
+	data, err := os.ReadFile(socketPath)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+}
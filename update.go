@@ -0,0 +1,99 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultUpdateTimeout is the default timeout for remote update checks.
+const DefaultUpdateTimeout = 5 * time.Second
+
+// maxUpdateResponseSize limits how much of the remote response is read.
+const maxUpdateResponseSize = 1 << 20
+
+// UpdateOptions configures CheckUpdateFromURL.
+type UpdateOptions struct {
+	// Client is the HTTP client used for the request, allowing callers to
+	// control TLS and proxies.
+	// Default: http.DefaultClient
+	Client *http.Client
+
+	// Timeout bounds the whole request, including reading the body.
+	// It applies in addition to any deadline on the context.
+	// Default: 5s
+	Timeout time.Duration
+}
+
+// UpdateResult is the outcome of a remote update check.
+type UpdateResult struct {
+	// Current is the version of the running application.
+	Current string `json:"current"`
+
+	// Latest is the version reported by the remote endpoint.
+	Latest string `json:"latest"`
+
+	// UpdateAvailable is true when Latest is a newer semantic version
+	// than Current. It is always false for non-semver current versions.
+	UpdateAvailable bool `json:"update_available"`
+}
+
+// CheckUpdateFromURL fetches the latest version from url and compares it to
+// the Info version. The endpoint must return a JSON object with a "version"
+// field, such as the output of Handler.
+func (i *Info) CheckUpdateFromURL(ctx context.Context, url string, opts ...UpdateOptions) (*UpdateResult, error) {
+	var opt UpdateOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Client == nil {
+		opt.Client = http.DefaultClient
+	}
+	if opt.Timeout <= 0 {
+		opt.Timeout = DefaultUpdateTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opt.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create update request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := opt.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch update info: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch update info: unexpected status %d", resp.StatusCode)
+	}
+
+	var remote struct {
+		Version string `json:"version"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxUpdateResponseSize)).Decode(&remote); err != nil {
+		return nil, fmt.Errorf("decode update info: %w", err)
+	}
+
+	latest, err := parseSemver(remote.Version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote version: %w", err)
+	}
+
+	result := &UpdateResult{
+		Current: i.Version,
+		Latest:  remote.Version,
+	}
+	if current, err := parseSemver(i.Version); err == nil {
+		result.UpdateAvailable = latest.compare(current) > 0
+	}
+
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package version
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUpdateServer(t *testing.T, version string, delay time.Duration) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version":"` + version + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestInfo_CheckUpdateFromURL(t *testing.T) {
+	server := newUpdateServer(t, "1.3.0", 0)
+
+	result, err := New("1.2.0", "", "").CheckUpdateFromURL(context.Background(), server.URL)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.0", result.Current)
+	assert.Equal(t, "1.3.0", result.Latest)
+	assert.True(t, result.UpdateAvailable)
+
+	result, err = New("1.3.0", "", "").CheckUpdateFromURL(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.False(t, result.UpdateAvailable)
+
+	result, err = New("dev", "", "").CheckUpdateFromURL(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.False(t, result.UpdateAvailable)
+}
+
+func TestInfo_CheckUpdateFromURL_Timeout(t *testing.T) {
+	server := newUpdateServer(t, "1.3.0", time.Second)
+
+	start := time.Now()
+	_, err := New("1.2.0", "", "").CheckUpdateFromURL(context.Background(), server.URL, UpdateOptions{
+		Timeout: 50 * time.Millisecond,
+	})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestInfo_CheckUpdateFromURL_CustomClientRespectsContext(t *testing.T) {
+	server := newUpdateServer(t, "1.3.0", time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := New("1.2.0", "", "").CheckUpdateFromURL(ctx, server.URL, UpdateOptions{
+		Client: &http.Client{},
+	})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestInfo_CheckUpdateFromURL_CustomClient(t *testing.T) {
+	server := newUpdateServer(t, "2.0.0", 0)
+
+	result, err := New("1.2.0", "", "").CheckUpdateFromURL(context.Background(), server.URL, UpdateOptions{
+		Client:  server.Client(),
+		Timeout: time.Second,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.UpdateAvailable)
+}
+
+func TestInfo_CheckUpdateFromURL_Errors(t *testing.T) {
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+
+	info := New("1.2.0", "", "")
+
+	_, err := info.CheckUpdateFromURL(context.Background(), notFound.URL)
+	assert.Error(t, err)
+
+	_, err = info.CheckUpdateFromURL(context.Background(), newUpdateServer(t, "latest", 0).URL)
+	assert.Error(t, err)
+
+	_, err = info.CheckUpdateFromURL(context.Background(), "://bad-url")
+	assert.Error(t, err)
+}
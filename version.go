@@ -5,11 +5,27 @@ package version
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 )
 
+// Unknown is the placeholder used for commit and build date values that
+// were not set at build time. It is treated as empty throughout the package.
+const Unknown = "unknown"
+
 // Default version variables - can be overridden via ldflags during build.
 // Example: go build -ldflags "-X github.com/soulteary/version-kit.Version=1.0.0"
 var (
@@ -17,29 +33,122 @@ var (
 	Version = "dev"
 
 	// Commit is the Git commit hash
-	Commit = "unknown"
+	Commit = Unknown
 
 	// BuildDate is the build timestamp
-	BuildDate = "unknown"
+	BuildDate = Unknown
 
 	// Branch is the Git branch name (optional)
 	Branch = ""
+
+	// BuildMeta is a JSON object with version metadata, letting a single
+	// -X flag set many fields at once (see FromBuildMeta)
+	BuildMeta = ""
+)
+
+// Bounds and default for the short commit length.
+const (
+	DefaultShortCommitLength = 7
+	MinShortCommitLength     = 4
+	MaxShortCommitLength     = 40
+)
+
+// shortCommitLength is the number of commit characters shown in short form.
+var shortCommitLength atomic.Int32
+
+func init() {
+	shortCommitLength.Store(DefaultShortCommitLength)
+}
+
+// SetShortCommitLength sets the number of characters used when shortening
+// commit hashes in String(), ShortCommit() and version headers.
+// n is clamped to [MinShortCommitLength, MaxShortCommitLength].
+func SetShortCommitLength(n int) {
+	if n < MinShortCommitLength {
+		n = MinShortCommitLength
+	}
+	if n > MaxShortCommitLength {
+		n = MaxShortCommitLength
+	}
+	shortCommitLength.Store(int32(n))
+}
+
+// ShortCommitLength returns the current short commit length.
+func ShortCommitLength() int {
+	return int(shortCommitLength.Load())
+}
+
+// now returns the current time. It is replaced in tests.
+var now = time.Now
+
+// customLayouts holds additional build date layouts registered by callers.
+var (
+	customLayoutsMu sync.RWMutex
+	customLayouts   []string
 )
 
+// RegisterBuildDateLayout registers an additional time layout that
+// BuildTimestamp() and CompileTimestamp() try after the built-in formats.
+func RegisterBuildDateLayout(layout string) {
+	if layout == "" {
+		return
+	}
+	customLayoutsMu.Lock()
+	defer customLayoutsMu.Unlock()
+	for _, l := range customLayouts {
+		if l == layout {
+			return
+		}
+	}
+	customLayouts = append(customLayouts, layout)
+}
+
 // Info holds version information for an application.
 type Info struct {
 	// Version is the semantic version number (e.g., "1.2.3")
 	Version string `json:"version"`
 
+	// Codename is the release codename (optional)
+	Codename string `json:"codename,omitempty"`
+
 	// Commit is the Git commit hash (short or full)
 	Commit string `json:"commit,omitempty"`
 
+	// CommitMessage is the subject line of the commit (optional)
+	CommitMessage string `json:"commit_message,omitempty"`
+
 	// BuildDate is the build timestamp in RFC3339 format
 	BuildDate string `json:"build_date,omitempty"`
 
 	// Branch is the Git branch name (optional)
 	Branch string `json:"branch,omitempty"`
 
+	// Repository is the source repository URL (optional)
+	Repository string `json:"repository,omitempty"`
+
+	// License is the SPDX license identifier (optional)
+	License string `json:"license,omitempty"`
+
+	// CompileDate is the time the binary was compiled, as opposed to
+	// BuildDate which holds the commit time (optional)
+	CompileDate string `json:"compile_date,omitempty"`
+
+	// SupportedUntil is the end-of-life date in RFC3339 format (optional)
+	SupportedUntil string `json:"supported_until,omitempty"`
+
+	// PipelineID is the CI pipeline or run identifier (optional)
+	PipelineID string `json:"pipeline_id,omitempty"`
+
+	// BuildNumber is the CI build number (optional)
+	BuildNumber string `json:"build_number,omitempty"`
+
+	// Extra holds arbitrary application-specific metadata (optional)
+	Extra map[string]any `json:"extra,omitempty"`
+
+	// Dirty reports whether the working tree had uncommitted changes
+	// at build time (vcs.modified)
+	Dirty bool `json:"dirty,omitempty"`
+
 	// GoVersion is the Go runtime version
 	GoVersion string `json:"go_version,omitempty"`
 
@@ -48,6 +157,18 @@ type Info struct {
 
 	// Compiler is the Go compiler used
 	Compiler string `json:"compiler,omitempty"`
+
+	// GoAMD64 is the GOAMD64 microarchitecture level, e.g. "v3" (optional)
+	GoAMD64 string `json:"goamd64,omitempty"`
+
+	// GoARM is the GOARM floating point variant, e.g. "7" (optional)
+	GoARM string `json:"goarm,omitempty"`
+
+	// NumCPU is the number of logical CPUs (optional, see NewRuntime)
+	NumCPU int `json:"num_cpu,omitempty"`
+
+	// MaxProcs is the GOMAXPROCS setting (optional, see NewRuntime)
+	MaxProcs int `json:"max_procs,omitempty"`
 }
 
 // New creates a new Info with the provided values.
@@ -62,6 +183,15 @@ func New(version, commit, buildDate string) *Info {
 	}
 }
 
+// NewRuntime creates a new Info like New and additionally records the
+// CPU configuration (NumCPU and GOMAXPROCS) of the running process.
+func NewRuntime(version, commit, buildDate string) *Info {
+	info := New(version, commit, buildDate)
+	info.NumCPU = runtime.NumCPU()
+	info.MaxProcs = runtime.GOMAXPROCS(0)
+	return info
+}
+
 // NewWithBranch creates a new Info with branch information.
 func NewWithBranch(version, commit, buildDate, branch string) *Info {
 	info := New(version, commit, buildDate)
@@ -71,38 +201,277 @@ func NewWithBranch(version, commit, buildDate, branch string) *Info {
 
 // Default returns an Info using the package-level variables.
 // This is useful when version info is set via ldflags.
+// Each call returns a new Info, so mutating it never affects the
+// package-level variables; use Freeze to share a snapshot safely.
 func Default() *Info {
 	return NewWithBranch(Version, Commit, BuildDate, Branch)
 }
 
+// Frozen is an immutable snapshot of an Info.
+//
+// The snapshot is only readable through Info(), which returns a fresh copy
+// on every call, so no code path holding a Frozen can change the values
+// seen by other holders. Share a Frozen (rather than an *Info) when the
+// same version information is handed to multiple goroutines or handlers.
+// Maps and slices in Extra are copied too; values behind pointers in Extra
+// are shared and must not be modified.
+type Frozen struct {
+	info Info
+}
+
+// Freeze returns an immutable snapshot of the Info. Later changes to i are
+// not reflected in the snapshot.
+func (i *Info) Freeze() Frozen {
+	return Frozen{info: *i.clone()}
+}
+
+// Info returns a mutable copy of the frozen Info.
+func (f Frozen) Info() *Info {
+	return f.info.clone()
+}
+
+// FromBuildMeta parses the BuildMeta JSON object into an Info.
+// Runtime fields missing from the JSON are filled from the running process.
+func FromBuildMeta() (*Info, error) {
+	if BuildMeta == "" {
+		return nil, fmt.Errorf("build metadata is empty")
+	}
+
+	info := New("", "", "")
+	if err := json.Unmarshal([]byte(BuildMeta), info); err != nil {
+		return nil, fmt.Errorf("parse build metadata: %w", err)
+	}
+	return info, nil
+}
+
+// FromEnv returns Default() enriched with CI metadata read from common
+// environment variables: PipelineID from GITHUB_RUN_ID or CI_PIPELINE_ID,
+// and BuildNumber from BUILD_NUMBER.
+func FromEnv() *Info {
+	info := Default()
+	info.PipelineID = firstEnv("GITHUB_RUN_ID", "CI_PIPELINE_ID")
+	info.BuildNumber = firstEnv("BUILD_NUMBER")
+	return info
+}
+
+// FromFile creates an Info from a VERSION file. The first line holds the
+// version; optional following "key=value" lines set the commit, branch and
+// build_date. Blank lines and unknown keys are ignored. Returns an error if
+// the file cannot be read or has no version.
+func FromFile(path string) (*Info, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read version file: %w", err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	version := strings.TrimSpace(lines[0])
+	if version == "" {
+		return nil, fmt.Errorf("version file %s has no version", path)
+	}
+
+	info := New(version, "", "")
+	for _, line := range lines[1:] {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "commit":
+			info.Commit = value
+		case "branch":
+			info.Branch = value
+		case "build_date":
+			info.BuildDate = value
+		}
+	}
+	return info, nil
+}
+
+// firstEnv returns the value of the first non-empty environment variable.
+func firstEnv(keys ...string) string {
+	for _, key := range keys {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// redacted returns a copy of i without runtime fields, CI metadata,
+// Repository and Extra, for HandlerConfig.Redact.
+func (i *Info) redacted() *Info {
+	return &Info{
+		Version:        i.Version,
+		Codename:       i.Codename,
+		Commit:         i.Commit,
+		CommitMessage:  i.CommitMessage,
+		BuildDate:      i.BuildDate,
+		Branch:         i.Branch,
+		License:        i.License,
+		CompileDate:    i.CompileDate,
+		SupportedUntil: i.SupportedUntil,
+	}
+}
+
 // String returns a human-readable version string.
-func (i *Info) String() string {
-	if i.Commit != "" && i.Commit != "unknown" {
-		shortCommit := i.Commit
-		if len(shortCommit) > 7 {
-			shortCommit = shortCommit[:7]
+func (f Frozen) String() string {
+	return f.info.String()
+}
+
+// clone returns a copy of the Info. Maps and slices nested in Extra are
+// copied too; pointers and other reference values are shared.
+func (i *Info) clone() *Info {
+	c := *i
+	if i.Extra != nil {
+		c.Extra = deepCopy(reflect.ValueOf(i.Extra)).Interface().(map[string]any)
+	}
+	return &c
+}
+
+// deepCopy returns a copy of v with nested maps, slices and arrays copied.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		return deepCopy(v.Elem())
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), convertTo(deepCopy(iter.Value()), v.Type().Elem()))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
 		}
-		return fmt.Sprintf("%s (%s)", i.Version, shortCommit)
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for idx := range v.Len() {
+			c.Index(idx).Set(convertTo(deepCopy(v.Index(idx)), v.Type().Elem()))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for idx := range v.Len() {
+			c.Index(idx).Set(convertTo(deepCopy(v.Index(idx)), v.Type().Elem()))
+		}
+		return c
+	default:
+		return v
 	}
-	return i.Version
+}
+
+// convertTo returns v as a value assignable to typ, wrapping it in an
+// interface or returning a zero value for invalid values.
+func convertTo(v reflect.Value, typ reflect.Type) reflect.Value {
+	if !v.IsValid() {
+		return reflect.Zero(typ)
+	}
+	if v.Type() == typ {
+		return v
+	}
+	c := reflect.New(typ).Elem()
+	c.Set(v)
+	return c
+}
+
+// String returns a human-readable version string.
+func (i *Info) String() string {
+	s := i.Version
+	if shortCommit := i.ShortCommit(); shortCommit != "" {
+		s = fmt.Sprintf("%s (%s)", s, shortCommit)
+	}
+	if message := i.ShortCommitMessage(); message != "" {
+		s += " " + message
+	}
+	return s
+}
+
+// DefaultCommitMessageLength is the default number of characters kept by
+// ShortCommitMessage.
+const DefaultCommitMessageLength = 50
+
+// commitMessageLength overrides DefaultCommitMessageLength when positive.
+var commitMessageLength atomic.Int32
+
+// SetCommitMessageLength sets the number of characters of the commit
+// message kept by String() and ShortCommitMessage(); n <= 0 restores
+// DefaultCommitMessageLength.
+func SetCommitMessageLength(n int) {
+	commitMessageLength.Store(int32(max(n, 0)))
+}
+
+// CommitMessageLength returns the current commit message length.
+func CommitMessageLength() int {
+	if n := commitMessageLength.Load(); n > 0 {
+		return int(n)
+	}
+	return DefaultCommitMessageLength
+}
+
+// ShortCommitMessage returns CommitMessage truncated to n characters
+// (CommitMessageLength() when omitted or not positive), ending with an
+// ellipsis when shortened.
+func (i *Info) ShortCommitMessage(n ...int) string {
+	limit := CommitMessageLength()
+	if len(n) > 0 && n[0] > 0 {
+		limit = n[0]
+	}
+
+	runes := []rune(i.CommitMessage)
+	if len(runes) <= limit {
+		return i.CommitMessage
+	}
+	return string(runes[:limit-1]) + "…"
 }
 
 // Full returns a detailed version string with all information.
 func (i *Info) Full() string {
 	result := fmt.Sprintf("Version:    %s\n", i.Version)
 
-	if i.Commit != "" && i.Commit != "unknown" {
+	if i.Codename != "" {
+		result += fmt.Sprintf("Codename:   %s\n", i.Codename)
+	}
+
+	if i.Commit != "" && i.Commit != Unknown {
 		result += fmt.Sprintf("Commit:     %s\n", i.Commit)
 	}
 
+	if i.CommitMessage != "" {
+		result += fmt.Sprintf("Message:    %s\n", i.CommitMessage)
+	}
+
 	if i.Branch != "" {
 		result += fmt.Sprintf("Branch:     %s\n", i.Branch)
 	}
 
-	if i.BuildDate != "" && i.BuildDate != "unknown" {
+	if i.BuildDate != "" && i.BuildDate != Unknown {
 		result += fmt.Sprintf("Built:      %s\n", i.BuildDate)
 	}
 
+	if i.CompileDate != "" && i.CompileDate != Unknown {
+		result += fmt.Sprintf("Compiled:   %s\n", i.CompileDate)
+	}
+
+	if i.PipelineID != "" {
+		result += fmt.Sprintf("Pipeline:   %s\n", i.PipelineID)
+	}
+
+	if i.BuildNumber != "" {
+		result += fmt.Sprintf("Build:      %s\n", i.BuildNumber)
+	}
+
+	if i.License != "" {
+		result += fmt.Sprintf("License:    %s\n", i.License)
+	}
+
 	result += fmt.Sprintf("Go version: %s\n", i.GoVersion)
 	result += fmt.Sprintf("Platform:   %s\n", i.Platform)
 	result += fmt.Sprintf("Compiler:   %s\n", i.Compiler)
@@ -110,6 +479,16 @@ func (i *Info) Full() string {
 	return result
 }
 
+// FullDimensions returns the length in runes of the longest line of Full()
+// and its number of lines.
+func (i *Info) FullDimensions() (width, height int) {
+	for _, line := range strings.Split(strings.TrimSuffix(i.Full(), "\n"), "\n") {
+		width = max(width, utf8.RuneCountInString(line))
+		height++
+	}
+	return width, height
+}
+
 // JSON returns the version info as a JSON string.
 func (i *Info) JSON() string {
 	data, err := json.Marshal(i)
@@ -128,6 +507,208 @@ func (i *Info) JSONPretty() string {
 	return string(data)
 }
 
+// NonEmpty returns the populated fields keyed by their JSON names with their
+// native types (e.g. Dirty stays a bool). Zero values, empty collections
+// and "unknown" placeholders are omitted.
+func (i *Info) NonEmpty() map[string]any {
+	result := make(map[string]any)
+
+	v := reflect.ValueOf(i).Elem()
+	t := v.Type()
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		value := v.Field(idx)
+		switch value.Kind() {
+		case reflect.Map, reflect.Slice:
+			if value.Len() == 0 {
+				continue
+			}
+		case reflect.String:
+			if value.String() == Unknown {
+				continue
+			}
+		}
+		if value.IsZero() {
+			continue
+		}
+
+		result[name] = value.Interface()
+	}
+
+	return result
+}
+
+// VersionSDL returns the GraphQL schema definition of an AppVersion type
+// mirroring the JSON fields of Info, named in camelCase. It is generated
+// from the struct so it stays in sync. Extra is omitted because GraphQL has
+// no generic map type; version is the only non-null field.
+func VersionSDL() string {
+	var b strings.Builder
+	b.WriteString("type AppVersion {\n")
+
+	t := reflect.TypeOf(Info{})
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || name == "" || !field.IsExported() {
+			continue
+		}
+
+		var gqlType string
+		switch field.Type.Kind() {
+		case reflect.String:
+			gqlType = "String"
+		case reflect.Bool:
+			gqlType = "Boolean"
+		case reflect.Int:
+			gqlType = "Int"
+		default:
+			continue
+		}
+		if !strings.Contains(opts, "omitempty") {
+			gqlType += "!"
+		}
+
+		parts := strings.Split(name, "_")
+		for p := 1; p < len(parts); p++ {
+			if parts[p] != "" {
+				parts[p] = strings.ToUpper(parts[p][:1]) + parts[p][1:]
+			}
+		}
+		fmt.Fprintf(&b, "  %s: %s\n", strings.Join(parts, ""), gqlType)
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// WriteFile writes the pretty-printed JSON version info to path.
+// Parent directories are created as needed and the file is replaced
+// atomically via a temporary file and rename.
+func (i *Info) WriteFile(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if _, err := tmp.WriteString(i.JSONPretty() + "\n"); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpName, 0o644); err != nil {
+		return fmt.Errorf("chmod temp file: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("rename temp file: %w", err)
+	}
+	return nil
+}
+
+// Format is an output format accepted by WriteFormat.
+type Format string
+
+// Supported output formats.
+const (
+	FormatJSON    Format = "json"
+	FormatYAML    Format = "yaml"
+	FormatText    Format = "text"
+	FormatMsgpack Format = "msgpack"
+)
+
+// WriteFormat writes the version info to w in the given format: pretty
+// JSON, flat YAML of Map() with sorted keys, the Full() text, or
+// MessagePack using the JSON field names.
+// Returns an error for unknown formats.
+func (i *Info) WriteFormat(w io.Writer, f Format) error {
+	switch f {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(i)
+	case FormatYAML:
+		m := i.Map()
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if _, err := fmt.Fprintf(w, "%s: %s\n", key, strconv.Quote(m[key])); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatText:
+		_, err := io.WriteString(w, i.Full())
+		return err
+	case FormatMsgpack:
+		return writeMsgpack(w, i)
+	default:
+		return fmt.Errorf("unknown format %q", f)
+	}
+}
+
+// LogBanner writes a startup banner with the application name and the
+// detailed version info to w. If info is nil, Default() will be used.
+func LogBanner(w io.Writer, appName string, info *Info) {
+	if info == nil {
+		info = Default()
+	}
+	if appName != "" {
+		_, _ = fmt.Fprintf(w, "%s %s\n", appName, info.String())
+	}
+	_, _ = io.WriteString(w, info.Full())
+}
+
+// logOnce guards LogOnce.
+var logOnce sync.Once
+
+// LogOnce logs the version info as a single structured record the first
+// time it is called; later calls in the same process do nothing. This makes
+// it safe to call from hot paths such as middleware. A nil logger uses
+// slog.Default() and a nil info uses Default().
+func LogOnce(logger *slog.Logger, info *Info) {
+	logOnce.Do(func() {
+		if logger == nil {
+			logger = slog.Default()
+		}
+		if info == nil {
+			info = Default()
+		}
+
+		m := info.Map()
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		attrs := make([]any, 0, len(keys))
+		for _, key := range keys {
+			attrs = append(attrs, slog.String(key, m[key]))
+		}
+		logger.Info("version info", attrs...)
+	})
+}
+
 // Map returns the version info as a map[string]string.
 func (i *Info) Map() map[string]string {
 	m := map[string]string{
@@ -137,7 +718,11 @@ func (i *Info) Map() map[string]string {
 		"compiler":   i.Compiler,
 	}
 
-	if i.Commit != "" && i.Commit != "unknown" {
+	if i.Codename != "" {
+		m["codename"] = i.Codename
+	}
+
+	if i.Commit != "" && i.Commit != Unknown {
 		m["commit"] = i.Commit
 	}
 
@@ -145,13 +730,304 @@ func (i *Info) Map() map[string]string {
 		m["branch"] = i.Branch
 	}
 
-	if i.BuildDate != "" && i.BuildDate != "unknown" {
+	if i.BuildDate != "" && i.BuildDate != Unknown {
 		m["build_date"] = i.BuildDate
 	}
 
+	if i.CompileDate != "" && i.CompileDate != Unknown {
+		m["compile_date"] = i.CompileDate
+	}
+
+	if i.PipelineID != "" {
+		m["pipeline_id"] = i.PipelineID
+	}
+
+	if i.NumCPU > 0 {
+		m["num_cpu"] = strconv.Itoa(i.NumCPU)
+	}
+
+	if i.MaxProcs > 0 {
+		m["max_procs"] = strconv.Itoa(i.MaxProcs)
+	}
+
+	if i.BuildNumber != "" {
+		m["build_number"] = i.BuildNumber
+	}
+
+	if i.License != "" {
+		m["license"] = i.License
+	}
+
+	if i.GoAMD64 != "" {
+		m["goamd64"] = i.GoAMD64
+	}
+
+	if i.GoARM != "" {
+		m["goarm"] = i.GoARM
+	}
+
 	return m
 }
 
+// StatusObject returns a minimal readiness object of the form
+// {"ready": true, "version": "1.2.3"}.
+func (i *Info) StatusObject(ready bool) map[string]any {
+	return map[string]any{
+		"ready":   ready,
+		"version": i.Version,
+	}
+}
+
+// commitURL returns the web URL of the commit in Repository, or an empty
+// string if either is unknown. SSH remotes are converted to https.
+func (i *Info) commitURL() string {
+	repo := repoWebURL(i.Repository)
+	if repo == "" || i.Commit == "" || i.Commit == Unknown {
+		return ""
+	}
+	return repo + "/commit/" + i.Commit
+}
+
+// ReleaseURL returns the web URL of the release tag for Version in
+// Repository, e.g. "https://github.com/org/repo/releases/tag/v1.2.3".
+// The tag prefix defaults to "v" and is not repeated when the version
+// already carries it in any case, so "V1.2.3" stays as is; pass "" for
+// unprefixed tags. SSH remotes such as
+// "git@github.com:org/repo.git" are converted to https. Returns an empty
+// string when Repository or Version is unset, or for development builds.
+func (i *Info) ReleaseURL(tagPrefix ...string) string {
+	base := repoWebURL(i.Repository)
+	if base == "" || i.IsDev() {
+		return ""
+	}
+
+	prefix := "v"
+	if len(tagPrefix) > 0 {
+		prefix = tagPrefix[0]
+	}
+	tag := i.Version
+	if len(tag) < len(prefix) || !strings.EqualFold(tag[:len(prefix)], prefix) {
+		tag = prefix + tag
+	}
+	return base + "/releases/tag/" + tag
+}
+
+// repoWebURL converts a repository URL or SSH remote into an https web URL
+// without a trailing slash or ".git" suffix.
+func repoWebURL(repo string) string {
+	repo = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(repo), "/"), ".git")
+	if repo == "" {
+		return ""
+	}
+
+	if rest, ok := strings.CutPrefix(repo, "ssh://"); ok {
+		if idx := strings.IndexByte(rest, '@'); idx >= 0 {
+			rest = rest[idx+1:]
+		}
+		return "https://" + rest
+	}
+	if !strings.Contains(repo, "://") {
+		if at := strings.IndexByte(repo, '@'); at >= 0 {
+			if host, path, ok := strings.Cut(repo[at+1:], ":"); ok {
+				return "https://" + host + "/" + path
+			}
+		}
+	}
+	return repo
+}
+
+// OCILabels returns the version info as OpenContainers image annotations.
+// The created label uses CompileDate when set, falling back to BuildDate.
+// Empty and unknown values are omitted.
+func (i *Info) OCILabels() map[string]string {
+	labels := make(map[string]string)
+
+	if i.Version != "" {
+		labels["org.opencontainers.image.version"] = i.Version
+	}
+
+	if i.Commit != "" && i.Commit != Unknown {
+		labels["org.opencontainers.image.revision"] = i.Commit
+	}
+
+	created := i.CompileDate
+	if created == "" || created == Unknown {
+		created = i.BuildDate
+	}
+	if created != "" && created != Unknown {
+		labels["org.opencontainers.image.created"] = created
+	}
+
+	if i.Repository != "" {
+		labels["org.opencontainers.image.source"] = i.Repository
+	}
+
+	if i.License != "" {
+		labels["org.opencontainers.image.licenses"] = i.License
+	}
+
+	return labels
+}
+
+// DockerTag returns a Docker image tag of the form "<version>-<short commit>"
+// (e.g. "1.2.3-abc1234"), or just the version when the commit is unknown.
+// An empty version is reported as "dev". Characters not allowed in Docker
+// tags are replaced with "-" and the result is truncated to 128 characters.
+func (i *Info) DockerTag() string {
+	version := i.Version
+	if version == "" {
+		version = "dev"
+	}
+
+	tag := version
+	if commit := i.ShortCommit(); commit != "" {
+		tag += "-" + commit
+	}
+
+	tag = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.' || r == '-' {
+			return r
+		}
+		return '-'
+	}, tag)
+
+	// Tags may not start with a period or hyphen
+	tag = strings.TrimLeft(tag, ".-")
+	if tag == "" {
+		tag = "dev"
+	}
+
+	if len(tag) > 128 {
+		tag = tag[:128]
+	}
+	return tag
+}
+
+// AnchorSlug returns the version as a URL-anchor-safe slug for changelog
+// links: the result is lowercased, the "v" prefix is stripped and runs of
+// characters other than letters and digits become a single dash, so
+// "v1.2.3" becomes "1-2-3" and "1.2.3-rc.1" becomes "1-2-3-rc-1".
+func (i *Info) AnchorSlug() string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.TrimPrefix(strings.ToLower(i.Version), "v") {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+// LabelValue returns a "<version>_<short commit>" string (or just the
+// version when the commit is unknown) that is safe to use as a metric
+// label value. Characters other than alphanumerics, dashes, dots and
+// underscores are replaced with "_".
+func (i *Info) LabelValue() string {
+	value := i.Version
+	if value == "" {
+		value = "dev"
+	}
+	if commit := i.ShortCommit(); commit != "" {
+		value += "_" + commit
+	}
+
+	return sanitizeLabel(value)
+}
+
+// PromLabels returns version, commit, branch and goversion labels for
+// Prometheus metrics. The result can be passed as prometheus.Labels. All
+// keys are always present, empty when unknown, so label sets stay
+// consistent; values are sanitized like LabelValue and the commit is
+// shortened.
+func (i *Info) PromLabels() map[string]string {
+	return map[string]string{
+		"version":   sanitizeLabel(i.Version),
+		"commit":    sanitizeLabel(i.ShortCommit()),
+		"branch":    sanitizeLabel(i.Branch),
+		"goversion": sanitizeLabel(i.GoVersion),
+	}
+}
+
+// ExemplarLabels returns commit and version labels for OpenMetrics
+// exemplars, tying traces to the build that produced them. The commit is
+// shortened to keep within the 128-character exemplar label limit and is
+// omitted when unknown.
+func (i *Info) ExemplarLabels() map[string]string {
+	labels := map[string]string{"version": i.Version}
+	if commit := i.ShortCommit(); commit != "" {
+		labels["commit"] = commit
+	}
+	return labels
+}
+
+// sanitizeLabel replaces characters other than alphanumerics, dashes, dots
+// and underscores with "_".
+func sanitizeLabel(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, value)
+}
+
+// EnvLines returns the fields of Map() as sorted NAME=value lines suitable
+// for a shell script, e.g. "APP_VERSION=1.2.3" for prefix "APP". Values are
+// single-quoted when they contain characters special to the shell.
+func (i *Info) EnvLines(prefix string) []string {
+	if prefix != "" && !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+
+	m := i.Map()
+	keys := make([]string, 0, len(m))
+	for key, value := range m {
+		if value != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, prefix+strings.ToUpper(key)+"="+shellQuote(m[key]))
+	}
+	return lines
+}
+
+// EnvString returns EnvLines as newline-separated export statements that
+// can be sourced by a shell.
+func (i *Info) EnvString(prefix string) string {
+	var b strings.Builder
+	for _, line := range i.EnvLines(prefix) {
+		b.WriteString("export ")
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// shellQuote single-quotes value unless it only contains safe characters.
+func shellQuote(value string) string {
+	safe := value != ""
+	for _, r := range value {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:+@%,=", r)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
 // Validate checks if the version info has valid required fields.
 func (i *Info) Validate() error {
 	if i.Version == "" {
@@ -160,20 +1036,173 @@ func (i *Info) Validate() error {
 	return nil
 }
 
+// ValidateCommit checks that the commit, when set and not "unknown",
+// is a hexadecimal hash of 7 to 40 characters.
+func (i *Info) ValidateCommit() error {
+	if i.Commit == "" || i.Commit == Unknown {
+		return nil
+	}
+	if len(i.Commit) < 7 || len(i.Commit) > 40 {
+		return fmt.Errorf("commit %q must be 7-40 characters", i.Commit)
+	}
+	for _, r := range i.Commit {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F') {
+			return fmt.Errorf("commit %q is not a hexadecimal hash", i.Commit)
+		}
+	}
+	return nil
+}
+
+// ValidateStrict runs Validate and additionally checks the commit format.
+func (i *Info) ValidateStrict() error {
+	if err := i.Validate(); err != nil {
+		return err
+	}
+	return i.ValidateCommit()
+}
+
+// ValidateAll runs every check and returns all failures joined with
+// errors.Join, or nil: the version must be set and, unless it is a
+// development version, be valid semver; the commit must pass
+// ValidateCommit; and a build date, when set, must be parseable.
+func (i *Info) ValidateAll() error {
+	var errs []error
+
+	if err := i.Validate(); err != nil {
+		errs = append(errs, err)
+	} else if !i.IsDev() {
+		if _, err := parseSemver(i.Version); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := i.ValidateCommit(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if i.BuildDate != "" && i.BuildDate != Unknown && i.BuildTimestamp().IsZero() {
+		errs = append(errs, fmt.Errorf("build date %q cannot be parsed", i.BuildDate))
+	}
+
+	return errors.Join(errs...)
+}
+
 // IsDev returns true if this is a development version.
 func (i *Info) IsDev() bool {
 	return i.Version == "dev" || i.Version == "development" || i.Version == ""
 }
 
+// IsClean returns true for a clean release build: the working tree was not
+// dirty, the version is not a development version, and the commit is known.
+func (i *Info) IsClean() bool {
+	return !i.Dirty && !i.IsDev() && i.Commit != "" && i.Commit != Unknown
+}
+
+// EqualCore reports whether i and other describe the same source build:
+// version, commit, branch and build date match. Runtime fields such as
+// GoVersion, Platform and Compiler are ignored.
+func (i *Info) EqualCore(other *Info) bool {
+	if i == nil || other == nil {
+		return i == other
+	}
+	return i.Version == other.Version &&
+		i.Commit == other.Commit &&
+		i.Branch == other.Branch &&
+		i.BuildDate == other.BuildDate
+}
+
+// DriftFrom reports whether i differs from canonical and which Map keys
+// drifted, in sorted order. Host-specific fields (platform, num_cpu and
+// max_procs) are ignored so nodes on different hardware do not drift.
+// A nil canonical is treated as an empty Info.
+func (i *Info) DriftFrom(canonical *Info) (bool, []string) {
+	if canonical == nil {
+		canonical = &Info{}
+	}
+
+	mine, theirs := i.Map(), canonical.Map()
+	keys := make(map[string]struct{}, len(mine)+len(theirs))
+	for key := range mine {
+		keys[key] = struct{}{}
+	}
+	for key := range theirs {
+		keys[key] = struct{}{}
+	}
+
+	var drifted []string
+	for key := range keys {
+		switch key {
+		case "platform", "num_cpu", "max_procs":
+			continue
+		}
+		if mine[key] != theirs[key] {
+			drifted = append(drifted, key)
+		}
+	}
+	sort.Strings(drifted)
+	return len(drifted) > 0, drifted
+}
+
+// IsEOL returns true when SupportedUntil is set and lies in the past.
+// An empty or unparseable date never reaches end of life.
+func (i *Info) IsEOL() bool {
+	until := parseTimestamp(i.SupportedUntil)
+	if until.IsZero() {
+		return false
+	}
+	return now().After(until)
+}
+
 // BuildTimestamp returns the build date as a time.Time.
 // Returns zero time if parsing fails.
 func (i *Info) BuildTimestamp() time.Time {
-	if i.BuildDate == "" || i.BuildDate == "unknown" {
+	return parseTimestamp(i.BuildDate)
+}
+
+// BuildAge returns the time elapsed since BuildDate according to the
+// package clock. ok is false when the build date cannot be parsed.
+func (i *Info) BuildAge() (age time.Duration, ok bool) {
+	ts := i.BuildTimestamp()
+	if ts.IsZero() {
+		return 0, false
+	}
+	return now().Sub(ts), true
+}
+
+// RecencyBucket classifies the build age for dashboards: "fresh" (under a
+// day), "recent" (under a week), "stale" (under 30 days) or "old".
+// Returns "unknown" when the build date cannot be parsed.
+func (i *Info) RecencyBucket() string {
+	age, ok := i.BuildAge()
+	switch {
+	case !ok:
+		return "unknown"
+	case age < 24*time.Hour:
+		return "fresh"
+	case age < 7*24*time.Hour:
+		return "recent"
+	case age < 30*24*time.Hour:
+		return "stale"
+	default:
+		return "old"
+	}
+}
+
+// CompileTimestamp returns the compile date as a time.Time.
+// Returns zero time if parsing fails.
+func (i *Info) CompileTimestamp() time.Time {
+	return parseTimestamp(i.CompileDate)
+}
+
+// parseTimestamp parses a date string using the supported layouts.
+// Returns zero time if parsing fails.
+func parseTimestamp(value string) time.Time {
+	if value == "" || value == Unknown {
 		return time.Time{}
 	}
 
 	// Try RFC3339 first
-	t, err := time.Parse(time.RFC3339, i.BuildDate)
+	t, err := time.Parse(time.RFC3339, value)
 	if err == nil {
 		return t
 	}
@@ -185,10 +1214,21 @@ func (i *Info) BuildTimestamp() time.Time {
 		"2006-01-02",
 		time.RFC1123,
 		time.RFC1123Z,
+		time.ANSIC,
+		time.UnixDate,
 	}
 
 	for _, format := range formats {
-		t, err = time.Parse(format, i.BuildDate)
+		t, err = time.Parse(format, value)
+		if err == nil {
+			return t
+		}
+	}
+
+	customLayoutsMu.RLock()
+	defer customLayoutsMu.RUnlock()
+	for _, layout := range customLayouts {
+		t, err = time.Parse(layout, value)
 		if err == nil {
 			return t
 		}
@@ -197,31 +1237,116 @@ func (i *Info) BuildTimestamp() time.Time {
 	return time.Time{}
 }
 
-// ShortCommit returns the first 7 characters of the commit hash.
+// ShortCommit returns the first ShortCommitLength() characters of the
+// commit hash (7 by default).
 func (i *Info) ShortCommit() string {
-	if i.Commit == "" || i.Commit == "unknown" {
+	if i.Commit == "" || i.Commit == Unknown {
 		return ""
 	}
-	if len(i.Commit) > 7 {
-		return i.Commit[:7]
+	if n := ShortCommitLength(); len(i.Commit) > n {
+		return i.Commit[:n]
 	}
 	return i.Commit
 }
 
+// maskedCommitLength is the number of commit characters kept by MaskedCommit.
+const maskedCommitLength = 4
+
+// MaskedCommit returns the first four characters of the commit followed by
+// an ellipsis (e.g. "abc1…") for public display. Commits of four characters
+// or fewer are returned unchanged; empty and unknown commits yield "".
+func (i *Info) MaskedCommit() string {
+	if i.Commit == "" || i.Commit == Unknown {
+		return ""
+	}
+	if len(i.Commit) > maskedCommitLength {
+		return i.Commit[:maskedCommitLength] + "…"
+	}
+	return i.Commit
+}
+
+// Platforms splits Platform into its entries. Platform may list several
+// comma-separated platforms for universal binaries and multi-arch images,
+// e.g. "darwin/amd64,darwin/arm64". Empty entries are skipped.
+func (i *Info) Platforms() []string {
+	var platforms []string
+	for _, platform := range strings.Split(i.Platform, ",") {
+		if platform = strings.TrimSpace(platform); platform != "" {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
+
+// PrimaryPlatform returns the first entry of Platforms, or an empty string.
+func (i *Info) PrimaryPlatform() string {
+	if platforms := i.Platforms(); len(platforms) > 0 {
+		return platforms[0]
+	}
+	return ""
+}
+
+// PlatformParts splits the primary platform into its OS and architecture
+// components. ok is false when it is not in the "os/arch" format.
+func (i *Info) PlatformParts() (os, arch string, ok bool) {
+	return splitPlatform(i.PrimaryPlatform())
+}
+
+// splitPlatform splits an "os/arch" platform string.
+func splitPlatform(platform string) (os, arch string, ok bool) {
+	os, arch, found := strings.Cut(platform, "/")
+	if !found || os == "" || arch == "" || strings.Contains(arch, "/") {
+		return "", "", false
+	}
+	return os, arch, true
+}
+
+// OS returns the operating system part of the primary platform
+// (e.g., "linux"). Returns an empty string if Platform is malformed.
+func (i *Info) OS() string {
+	os, _, _ := i.PlatformParts()
+	return os
+}
+
+// Arch returns the architecture part of the primary platform
+// (e.g., "amd64"). Returns an empty string if Platform is malformed.
+func (i *Info) Arch() string {
+	_, arch, _ := i.PlatformParts()
+	return arch
+}
+
+// MatchesRuntime reports whether any of the listed platforms equals the
+// platform of the running process (runtime.GOOS/runtime.GOARCH).
+func (i *Info) MatchesRuntime() bool {
+	for _, platform := range i.Platforms() {
+		if os, arch, ok := splitPlatform(platform); ok && os == runtime.GOOS && arch == runtime.GOARCH {
+			return true
+		}
+	}
+	return false
+}
+
 // Builder provides a fluent interface for creating Info.
 type Builder struct {
 	info *Info
+	errs []error
 }
 
 // NewBuilder creates a new Builder.
 func NewBuilder() *Builder {
-	return &Builder{
-		info: &Info{
-			GoVersion: runtime.Version(),
-			Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
-			Compiler:  runtime.Compiler,
-		},
+	return (&Builder{}).Reset()
+}
+
+// Reset clears all fields set on the builder and restores the runtime
+// defaults, so the builder can be reused to construct another Info.
+func (b *Builder) Reset() *Builder {
+	b.info = &Info{
+		GoVersion: runtime.Version(),
+		Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+		Compiler:  runtime.Compiler,
 	}
+	b.errs = nil
+	return b
 }
 
 // WithVersion sets the version.
@@ -230,25 +1355,112 @@ func (b *Builder) WithVersion(version string) *Builder {
 	return b
 }
 
+// WithCodename sets the release codename.
+func (b *Builder) WithCodename(codename string) *Builder {
+	b.info.Codename = codename
+	return b
+}
+
 // WithCommit sets the commit hash.
 func (b *Builder) WithCommit(commit string) *Builder {
 	b.info.Commit = commit
 	return b
 }
 
+// WithCommitMessage sets the commit subject line.
+func (b *Builder) WithCommitMessage(message string) *Builder {
+	b.info.CommitMessage = message
+	return b
+}
+
 // WithBuildDate sets the build date.
 func (b *Builder) WithBuildDate(buildDate string) *Builder {
 	b.info.BuildDate = buildDate
 	return b
 }
 
+// WithBuildDateValidated sets the build date and records an error,
+// reported by BuildValidated, if it cannot be parsed like BuildTimestamp.
+func (b *Builder) WithBuildDateValidated(buildDate string) *Builder {
+	b.info.BuildDate = buildDate
+	if parseTimestamp(buildDate).IsZero() {
+		b.errs = append(b.errs, fmt.Errorf("build date %q cannot be parsed", buildDate))
+	}
+	return b
+}
+
+// WithCompileDate sets the compile date.
+func (b *Builder) WithCompileDate(compileDate string) *Builder {
+	b.info.CompileDate = compileDate
+	return b
+}
+
 // WithBranch sets the branch name.
 func (b *Builder) WithBranch(branch string) *Builder {
 	b.info.Branch = branch
 	return b
 }
 
-// Build returns the constructed Info.
+// WithDirty sets whether the working tree was dirty at build time.
+func (b *Builder) WithDirty(dirty bool) *Builder {
+	b.info.Dirty = dirty
+	return b
+}
+
+// WithRepository sets the source repository URL.
+func (b *Builder) WithRepository(repository string) *Builder {
+	b.info.Repository = repository
+	return b
+}
+
+// WithLicense sets the SPDX license identifier.
+func (b *Builder) WithLicense(license string) *Builder {
+	b.info.License = license
+	return b
+}
+
+// WithPipelineID sets the CI pipeline identifier.
+func (b *Builder) WithPipelineID(pipelineID string) *Builder {
+	b.info.PipelineID = pipelineID
+	return b
+}
+
+// WithBuildNumber sets the CI build number.
+func (b *Builder) WithBuildNumber(buildNumber string) *Builder {
+	b.info.BuildNumber = buildNumber
+	return b
+}
+
+// WithRuntimeInfo records the CPU configuration of the running process.
+func (b *Builder) WithRuntimeInfo() *Builder {
+	b.info.NumCPU = runtime.NumCPU()
+	b.info.MaxProcs = runtime.GOMAXPROCS(0)
+	return b
+}
+
+// WithExtra sets an application-specific metadata entry.
+func (b *Builder) WithExtra(key string, value any) *Builder {
+	if b.info.Extra == nil {
+		b.info.Extra = make(map[string]any)
+	}
+	b.info.Extra[key] = value
+	return b
+}
+
+// WithSupportedUntil sets the end-of-life date (RFC3339).
+func (b *Builder) WithSupportedUntil(supportedUntil string) *Builder {
+	b.info.SupportedUntil = supportedUntil
+	return b
+}
+
+// Build returns the constructed Info. Each call returns a new copy, so the
+// result is independent of later changes to the builder.
 func (b *Builder) Build() *Info {
-	return b.info
+	return b.info.clone()
+}
+
+// BuildValidated returns the built Info together with the errors recorded
+// by validating setters, joined with errors.Join, or nil.
+func (b *Builder) BuildValidated() (*Info, error) {
+	return b.info.clone(), errors.Join(b.errs...)
 }
@@ -1,9 +1,16 @@
 package version
 
 import (
+	"bytes"
 	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -22,6 +29,35 @@ func TestNew(t *testing.T) {
 	assert.NotEmpty(t, info.Compiler)
 }
 
+func TestNewRuntime(t *testing.T) {
+	info := NewRuntime("1.0.0", "abc123", "")
+
+	assert.Equal(t, "1.0.0", info.Version)
+	assert.Positive(t, info.NumCPU)
+	assert.Positive(t, info.MaxProcs)
+
+	m := info.Map()
+	assert.Equal(t, strconv.Itoa(info.NumCPU), m["num_cpu"])
+	assert.Equal(t, strconv.Itoa(info.MaxProcs), m["max_procs"])
+}
+
+func TestNew_NoRuntimeInfo(t *testing.T) {
+	info := New("1.0.0", "abc123", "")
+
+	assert.Zero(t, info.NumCPU)
+	assert.Zero(t, info.MaxProcs)
+	_, ok := info.Map()["num_cpu"]
+	assert.False(t, ok)
+	assert.NotContains(t, info.JSON(), "num_cpu")
+}
+
+func TestBuilder_WithRuntimeInfo(t *testing.T) {
+	info := NewBuilder().WithVersion("1.0.0").WithRuntimeInfo().Build()
+
+	assert.Equal(t, runtime.NumCPU(), info.NumCPU)
+	assert.Equal(t, runtime.GOMAXPROCS(0), info.MaxProcs)
+}
+
 func TestNewWithBranch(t *testing.T) {
 	info := NewWithBranch("1.0.0", "abc123", "2025-01-01T00:00:00Z", "main")
 
@@ -58,6 +94,170 @@ func TestDefault(t *testing.T) {
 	assert.Equal(t, "develop", info.Branch)
 }
 
+func TestFromBuildMeta(t *testing.T) {
+	orig := BuildMeta
+	defer func() { BuildMeta = orig }()
+
+	BuildMeta = `{"version":"1.2.3","commit":"abc123","branch":"main","dirty":true,"extra":{"team":"core"}}`
+
+	info, err := FromBuildMeta()
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", info.Version)
+	assert.Equal(t, "abc123", info.Commit)
+	assert.Equal(t, "main", info.Branch)
+	assert.True(t, info.Dirty)
+	assert.Equal(t, "core", info.Extra["team"])
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+
+	BuildMeta = `{"version":"1.2.3","go_version":"go1.20"}`
+	info, err = FromBuildMeta()
+	require.NoError(t, err)
+	assert.Equal(t, "go1.20", info.GoVersion)
+}
+
+func TestFromBuildMeta_Errors(t *testing.T) {
+	orig := BuildMeta
+	defer func() { BuildMeta = orig }()
+
+	BuildMeta = ""
+	_, err := FromBuildMeta()
+	assert.Error(t, err)
+
+	BuildMeta = "{not json"
+	_, err = FromBuildMeta()
+	assert.Error(t, err)
+}
+
+func TestFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "VERSION")
+	require.NoError(t, os.WriteFile(path, []byte("1.4.2\ncommit=abc1234567890\nbranch = main\n\nunknown=x\n"), 0o644))
+
+	info, err := FromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "1.4.2", info.Version)
+	assert.Equal(t, "abc1234567890", info.Commit)
+	assert.Equal(t, "main", info.Branch)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+
+	require.NoError(t, os.WriteFile(path, []byte(" 2.0.0 \r\n"), 0o644))
+	info, err = FromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", info.Version)
+	assert.Empty(t, info.Commit)
+}
+
+func TestFromFile_Errors(t *testing.T) {
+	_, err := FromFile(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "VERSION")
+	require.NoError(t, os.WriteFile(path, []byte("\ncommit=abc1234\n"), 0o644))
+	_, err = FromFile(path)
+	assert.Error(t, err)
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("GITHUB_RUN_ID", "123456")
+	t.Setenv("CI_PIPELINE_ID", "789")
+	t.Setenv("BUILD_NUMBER", "42")
+
+	info := FromEnv()
+
+	assert.Equal(t, Version, info.Version)
+	assert.Equal(t, "123456", info.PipelineID)
+	assert.Equal(t, "42", info.BuildNumber)
+
+	full := info.Full()
+	assert.Contains(t, full, "Pipeline:   123456")
+	assert.Contains(t, full, "Build:      42")
+
+	m := info.Map()
+	assert.Equal(t, "123456", m["pipeline_id"])
+	assert.Equal(t, "42", m["build_number"])
+	assert.Contains(t, info.JSON(), `"pipeline_id":"123456"`)
+}
+
+func TestFromEnv_GitLab(t *testing.T) {
+	t.Setenv("GITHUB_RUN_ID", "")
+	t.Setenv("CI_PIPELINE_ID", "789")
+	t.Setenv("BUILD_NUMBER", "")
+
+	info := FromEnv()
+
+	assert.Equal(t, "789", info.PipelineID)
+	assert.Empty(t, info.BuildNumber)
+	assert.NotContains(t, info.Full(), "Build:      ")
+	_, ok := info.Map()["build_number"]
+	assert.False(t, ok)
+}
+
+func TestBuilder_CIMetadata(t *testing.T) {
+	info := NewBuilder().
+		WithVersion("1.0.0").
+		WithPipelineID("run-1").
+		WithBuildNumber("7").
+		Build()
+
+	assert.Equal(t, "run-1", info.PipelineID)
+	assert.Equal(t, "7", info.BuildNumber)
+}
+
+func TestInfo_Freeze(t *testing.T) {
+	info := NewWithBranch("1.0.0", "abc1234567890", "2025-01-01T00:00:00Z", "main")
+	frozen := info.Freeze()
+
+	// Mutating the source does not affect the snapshot
+	info.Version = "2.0.0"
+	info.Branch = "develop"
+	assert.Equal(t, "1.0.0", frozen.Info().Version)
+	assert.Equal(t, "main", frozen.Info().Branch)
+
+	// Mutating a copy obtained from the snapshot does not affect it either
+	copied := frozen.Info()
+	copied.Version = "3.0.0"
+	copied.Commit = "changed"
+	assert.Equal(t, "1.0.0", frozen.Info().Version)
+	assert.Equal(t, "abc1234567890", frozen.Info().Commit)
+	assert.Equal(t, "1.0.0 (abc1234)", frozen.String())
+
+	// Each call returns a distinct copy
+	assert.NotSame(t, frozen.Info(), frozen.Info())
+}
+
+func TestInfo_Freeze_NestedExtra(t *testing.T) {
+	info := New("1.0.0", "", "")
+	info.Extra = map[string]any{
+		"tags":  []string{"a", "b"},
+		"owner": map[string]any{"team": "core", "ids": []any{1, nil}},
+		"none":  nil,
+	}
+	frozen := info.Freeze()
+
+	info.Extra["tags"].([]string)[0] = "MUT"
+	frozen.Info().Extra["tags"].([]string)[1] = "MUT"
+	frozen.Info().Extra["owner"].(map[string]any)["team"] = "MUT"
+	frozen.Info().Extra["owner"].(map[string]any)["ids"].([]any)[0] = "MUT"
+
+	extra := frozen.Info().Extra
+	assert.Equal(t, []string{"a", "b"}, extra["tags"])
+	assert.Equal(t, map[string]any{"team": "core", "ids": []any{1, nil}}, extra["owner"])
+	assert.Contains(t, extra, "none")
+	assert.Nil(t, extra["none"])
+}
+
+func TestDefault_ReturnsCopy(t *testing.T) {
+	origVersion := Version
+	defer func() { Version = origVersion }()
+	Version = "1.0.0"
+
+	info := Default()
+	info.Version = "mutated"
+
+	assert.Equal(t, "1.0.0", Version)
+	assert.Equal(t, "1.0.0", Default().Version)
+}
+
 func TestInfo_String(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -121,6 +321,97 @@ func TestInfo_Full_Minimal(t *testing.T) {
 	assert.NotContains(t, full, "Built:")
 }
 
+func TestInfo_Codename(t *testing.T) {
+	info := NewBuilder().WithVersion("1.4.0").WithCodename("Fuji").Build()
+
+	assert.Contains(t, info.Full(), "Codename:   Fuji")
+	assert.Equal(t, "Fuji", info.Map()["codename"])
+	assert.Contains(t, info.JSON(), `"codename":"Fuji"`)
+
+	info.Codename = ""
+	assert.NotContains(t, info.Full(), "Codename:")
+	assert.NotContains(t, info.Map(), "codename")
+	assert.NotContains(t, info.JSON(), "codename")
+}
+
+func TestInfo_License(t *testing.T) {
+	info := NewBuilder().WithVersion("1.4.0").WithLicense("Apache-2.0").Build()
+
+	assert.Contains(t, info.Full(), "License:    Apache-2.0")
+	assert.Equal(t, "Apache-2.0", info.Map()["license"])
+	assert.Contains(t, info.JSON(), `"license":"Apache-2.0"`)
+	assert.Equal(t, "Apache-2.0", info.OCILabels()["org.opencontainers.image.licenses"])
+
+	info.License = ""
+	assert.NotContains(t, info.Full(), "License:")
+	assert.NotContains(t, info.Map(), "license")
+	assert.NotContains(t, info.JSON(), "license")
+}
+
+func TestInfo_FullDimensions(t *testing.T) {
+	info := &Info{Version: "1.2.3", Branch: "功能", GoVersion: "go1.26", Platform: "linux/amd64", Compiler: "gc"}
+
+	width, height := info.FullDimensions()
+	assert.Equal(t, 5, height)
+	assert.Equal(t, len("Platform:   linux/amd64"), width)
+
+	info.Commit = "abc1234"
+	info.Repository = "https://example.com/repo"
+	_, height = info.FullDimensions()
+	assert.Equal(t, 6, height)
+}
+
+func TestInfo_CommitMessage(t *testing.T) {
+	info := NewBuilder().WithVersion("1.2.3").WithCommit("abc1234567890").WithCommitMessage("Fix crash").Build()
+
+	assert.Contains(t, info.Full(), "Message:    Fix crash\n")
+	assert.Contains(t, info.JSON(), `"commit_message":"Fix crash"`)
+	assert.Equal(t, "1.2.3 (abc1234) Fix crash", info.String())
+
+	info.CommitMessage = strings.Repeat("x", 80)
+	assert.Equal(t, strings.Repeat("x", 49)+"…", info.ShortCommitMessage())
+	assert.Equal(t, "xxxxxxxxx…", info.ShortCommitMessage(10))
+	assert.Contains(t, info.String(), strings.Repeat("x", 49)+"…")
+	assert.Contains(t, info.Full(), info.CommitMessage)
+
+	info.CommitMessage = ""
+	assert.Equal(t, "1.2.3 (abc1234)", info.String())
+	assert.NotContains(t, info.Full(), "Message:")
+}
+
+func TestSetCommitMessageLength(t *testing.T) {
+	defer SetCommitMessageLength(0)
+	info := NewBuilder().WithVersion("1.2.3").WithCommitMessage(strings.Repeat("x", 80)).Build()
+
+	SetCommitMessageLength(10)
+	assert.Equal(t, 10, CommitMessageLength())
+	assert.Equal(t, "1.2.3 xxxxxxxxx…", info.String())
+	assert.Equal(t, "xxxx…", info.ShortCommitMessage(5))
+
+	SetCommitMessageLength(0)
+	assert.Equal(t, DefaultCommitMessageLength, CommitMessageLength())
+	assert.Equal(t, strings.Repeat("x", 49)+"…", info.ShortCommitMessage())
+}
+
+func TestVersionSDL(t *testing.T) {
+	sdl := VersionSDL()
+
+	assert.True(t, strings.HasPrefix(sdl, "type AppVersion {\n"))
+	assert.True(t, strings.HasSuffix(sdl, "}\n"))
+	for _, line := range []string{
+		"  version: String!\n",
+		"  commit: String\n",
+		"  buildDate: String\n",
+		"  pipelineId: String\n",
+		"  dirty: Boolean\n",
+		"  goVersion: String\n",
+		"  numCpu: Int\n",
+	} {
+		assert.Contains(t, sdl, line)
+	}
+	assert.NotContains(t, sdl, "extra")
+}
+
 func TestInfo_JSON(t *testing.T) {
 	info := New("1.0.0", "abc123", "2025-01-01T00:00:00Z")
 	jsonStr := info.JSON()
@@ -148,6 +439,111 @@ func TestInfo_JSONPretty(t *testing.T) {
 	assert.Equal(t, "1.0.0", parsed.Version)
 }
 
+func TestInfo_NonEmpty(t *testing.T) {
+	info := &Info{
+		Version:   "1.0.0",
+		Commit:    "unknown",
+		BuildDate: "2025-01-01T00:00:00Z",
+		Dirty:     true,
+		NumCPU:    8,
+		Extra:     map[string]any{},
+	}
+
+	fields := info.NonEmpty()
+
+	assert.Equal(t, map[string]any{
+		"version":    "1.0.0",
+		"build_date": "2025-01-01T00:00:00Z",
+		"dirty":      true,
+		"num_cpu":    8,
+	}, fields)
+
+	dirty, ok := fields["dirty"].(bool)
+	assert.True(t, ok)
+	assert.True(t, dirty)
+	assert.NotContains(t, fields, "commit")
+	assert.NotContains(t, fields, "branch")
+	assert.NotContains(t, fields, "extra")
+}
+
+func TestInfo_WriteFile(t *testing.T) {
+	info := NewWithBranch("1.0.0", "abc123", "2025-01-01T00:00:00Z", "main")
+	path := filepath.Join(t.TempDir(), "nested", "dir", "version.json")
+
+	require.NoError(t, info.WriteFile(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var parsed Info
+	require.NoError(t, json.Unmarshal(data, &parsed))
+	assert.Equal(t, "1.0.0", parsed.Version)
+	assert.Equal(t, "abc123", parsed.Commit)
+	assert.Equal(t, "main", parsed.Branch)
+
+	// Overwriting should replace the file and leave no temp files behind
+	info.Version = "1.0.1"
+	require.NoError(t, info.WriteFile(path))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"version": "1.0.1"`)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestInfo_WriteFile_InvalidPath(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(blocker, []byte("x"), 0o644))
+
+	err := New("1.0.0", "", "").WriteFile(filepath.Join(blocker, "version.json"))
+	assert.Error(t, err)
+}
+
+func TestInfo_WriteFormat(t *testing.T) {
+	info := New("1.2.3", "abc1234567890", "2025-01-01T00:00:00Z")
+
+	var buf bytes.Buffer
+	require.NoError(t, info.WriteFormat(&buf, FormatJSON))
+	var parsed Info
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
+	assert.Equal(t, "1.2.3", parsed.Version)
+
+	buf.Reset()
+	require.NoError(t, info.WriteFormat(&buf, FormatYAML))
+	assert.Contains(t, buf.String(), "version: \"1.2.3\"\n")
+	assert.Contains(t, buf.String(), "commit: \"abc1234567890\"\n")
+
+	buf.Reset()
+	require.NoError(t, info.WriteFormat(&buf, FormatText))
+	assert.Equal(t, info.Full(), buf.String())
+
+	buf.Reset()
+	assert.Error(t, info.WriteFormat(&buf, Format("xml")))
+	assert.Empty(t, buf.String())
+}
+
+func TestLogBanner(t *testing.T) {
+	info := NewWithBranch("1.0.0", "abc1234567890", "2025-01-01T00:00:00Z", "main")
+
+	var buf bytes.Buffer
+	LogBanner(&buf, "myapp", info)
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "myapp 1.0.0 (abc1234)\n"))
+	assert.Contains(t, out, "Version:    1.0.0")
+	assert.Contains(t, out, "Branch:     main")
+}
+
+func TestLogBanner_NoAppNameNilInfo(t *testing.T) {
+	var buf bytes.Buffer
+	LogBanner(&buf, "", nil)
+
+	assert.Equal(t, Default().Full(), buf.String())
+}
+
 func TestInfo_Map(t *testing.T) {
 	info := NewWithBranch("1.0.0", "abc123", "2025-01-01T00:00:00Z", "main")
 	m := info.Map()
@@ -177,6 +573,191 @@ func TestInfo_Map_Minimal(t *testing.T) {
 	assert.False(t, hasBranch)
 }
 
+func TestInfo_ReleaseURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		repo   string
+		ver    string
+		prefix []string
+		want   string
+	}{
+		{"https", "https://github.com/org/repo", "1.2.3", nil, "https://github.com/org/repo/releases/tag/v1.2.3"},
+		{"https with .git", "https://github.com/org/repo.git/", "v1.2.3", nil, "https://github.com/org/repo/releases/tag/v1.2.3"},
+		{"scp-like ssh", "git@github.com:org/repo.git", "1.2.3", nil, "https://github.com/org/repo/releases/tag/v1.2.3"},
+		{"ssh url", "ssh://git@github.com/org/repo.git", "1.2.3", nil, "https://github.com/org/repo/releases/tag/v1.2.3"},
+		{"uppercase prefix", "https://github.com/org/repo", "V1.2.3", nil, "https://github.com/org/repo/releases/tag/V1.2.3"},
+		{"custom prefix case", "https://github.com/org/repo", "Release-1.2.3", []string{"release-"}, "https://github.com/org/repo/releases/tag/Release-1.2.3"},
+		{"no prefix", "https://github.com/org/repo", "1.2.3", []string{""}, "https://github.com/org/repo/releases/tag/1.2.3"},
+		{"no repository", "", "1.2.3", nil, ""},
+		{"dev", "https://github.com/org/repo", "dev", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &Info{Version: tt.ver, Repository: tt.repo}
+			assert.Equal(t, tt.want, info.ReleaseURL(tt.prefix...))
+		})
+	}
+}
+
+func TestInfo_OCILabels(t *testing.T) {
+	info := NewBuilder().
+		WithVersion("1.0.0").
+		WithCommit("abc1234567890").
+		WithBuildDate("2025-01-01T00:00:00Z").
+		WithRepository("https://github.com/soulteary/version-kit").
+		Build()
+
+	assert.Equal(t, map[string]string{
+		"org.opencontainers.image.version":  "1.0.0",
+		"org.opencontainers.image.revision": "abc1234567890",
+		"org.opencontainers.image.created":  "2025-01-01T00:00:00Z",
+		"org.opencontainers.image.source":   "https://github.com/soulteary/version-kit",
+	}, info.OCILabels())
+
+	info.CompileDate = "2025-01-02T00:00:00Z"
+	assert.Equal(t, "2025-01-02T00:00:00Z", info.OCILabels()["org.opencontainers.image.created"])
+}
+
+func TestInfo_OCILabels_Minimal(t *testing.T) {
+	info := New("1.0.0", "unknown", "unknown")
+
+	assert.Equal(t, map[string]string{
+		"org.opencontainers.image.version": "1.0.0",
+	}, info.OCILabels())
+}
+
+func TestInfo_DockerTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		commit   string
+		expected string
+	}{
+		{"release", "1.2.3", "abc1234567890", "1.2.3-abc1234"},
+		{"v prefix", "v1.2.3", "abc1234", "v1.2.3-abc1234"},
+		{"dev", "dev", "abc1234567890", "dev-abc1234"},
+		{"empty version", "", "abc1234", "dev-abc1234"},
+		{"unknown commit", "1.2.3", "unknown", "1.2.3"},
+		{"empty commit", "1.2.3", "", "1.2.3"},
+		{"build metadata", "1.2.3+build.5", "", "1.2.3-build.5"},
+		{"slashes", "feature/foo", "abc1234", "feature-foo-abc1234"},
+		{"leading hyphen", "-rc", "", "rc"},
+		{"only invalid", "///", "", "dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &Info{Version: tt.version, Commit: tt.commit}
+			assert.Equal(t, tt.expected, info.DockerTag())
+		})
+	}
+}
+
+func TestInfo_DockerTag_Truncated(t *testing.T) {
+	info := &Info{Version: strings.Repeat("1", 200)}
+	assert.Len(t, info.DockerTag(), 128)
+}
+
+func TestInfo_PromLabels(t *testing.T) {
+	info := &Info{Version: "1.2.3+build 5", Commit: "abc1234567890", Branch: "feature/x", GoVersion: "go1.26.0"}
+	assert.Equal(t, map[string]string{
+		"version":   "1.2.3_build_5",
+		"commit":    "abc1234",
+		"branch":    "feature_x",
+		"goversion": "go1.26.0",
+	}, info.PromLabels())
+
+	empty := (&Info{Commit: Unknown}).PromLabels()
+	assert.Len(t, empty, 4)
+	assert.Empty(t, empty["commit"])
+	assert.Empty(t, empty["branch"])
+}
+
+func TestInfo_ExemplarLabels(t *testing.T) {
+	info := &Info{Version: "1.2.3", Commit: "abc1234567890", Branch: "main"}
+	assert.Equal(t, map[string]string{"commit": "abc1234", "version": "1.2.3"}, info.ExemplarLabels())
+
+	assert.Equal(t, map[string]string{"version": "1.2.3"}, (&Info{Version: "1.2.3"}).ExemplarLabels())
+	assert.Equal(t, map[string]string{"version": "1.2.3"}, (&Info{Version: "1.2.3", Commit: Unknown}).ExemplarLabels())
+}
+
+func TestInfo_AnchorSlug(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"v1.2.3", "1-2-3"},
+		{"V1.2.3", "1-2-3"},
+		{"1.2.3-rc.1", "1-2-3-rc-1"},
+		{"1.2.3-RC.1+build.5", "1-2-3-rc-1-build-5"},
+		{"1.2.3--beta", "1-2-3-beta"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, (&Info{Version: tt.version}).AnchorSlug())
+		})
+	}
+}
+
+func TestInfo_LabelValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		commit   string
+		expected string
+	}{
+		{"release", "1.2.3", "abc1234567890", "1.2.3_abc1234"},
+		{"no commit", "1.2.3", "", "1.2.3"},
+		{"unknown commit", "1.2.3", "unknown", "1.2.3"},
+		{"empty version", "", "abc1234", "dev_abc1234"},
+		{"special characters", "1.2.3+build/5 (rc)", "abc1234", "1.2.3_build_5__rc__abc1234"},
+		{"unicode", "版本1", "", "__1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &Info{Version: tt.version, Commit: tt.commit}
+			assert.Equal(t, tt.expected, info.LabelValue())
+		})
+	}
+}
+
+func TestInfo_EnvLines(t *testing.T) {
+	info := &Info{
+		Version:   "1.2.3",
+		Commit:    "abc123",
+		Branch:    "feature branch",
+		BuildDate: "unknown",
+		GoVersion: "go1.26",
+		Platform:  "linux/amd64",
+		Compiler:  "it's gc",
+	}
+
+	assert.Equal(t, []string{
+		"APP_BRANCH='feature branch'",
+		"APP_COMMIT=abc123",
+		`APP_COMPILER='it'\''s gc'`,
+		"APP_GO_VERSION=go1.26",
+		"APP_PLATFORM=linux/amd64",
+		"APP_VERSION=1.2.3",
+	}, info.EnvLines("APP"))
+
+	assert.Equal(t, "VERSION=1.2.3", info.EnvLines("")[5])
+	assert.Equal(t, "APP_VERSION=1.2.3", info.EnvLines("APP_")[5])
+}
+
+func TestInfo_EnvString(t *testing.T) {
+	info := &Info{Version: "1.2.3", GoVersion: "go1.26", Platform: "linux/amd64", Compiler: "gc"}
+
+	assert.Equal(t, "export X_COMPILER=gc\n"+
+		"export X_GO_VERSION=go1.26\n"+
+		"export X_PLATFORM=linux/amd64\n"+
+		"export X_VERSION=1.2.3\n", info.EnvString("X"))
+}
+
 func TestInfo_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -207,6 +788,42 @@ func TestInfo_Validate(t *testing.T) {
 	}
 }
 
+func TestInfo_ValidateCommit(t *testing.T) {
+	tests := []struct {
+		name    string
+		commit  string
+		wantErr bool
+	}{
+		{"full hash", "0123456789abcdef0123456789abcdef01234567", false},
+		{"short hash", "abc1234", false},
+		{"uppercase", "ABC1234", false},
+		{"empty", "", false},
+		{"unknown", "unknown", false},
+		{"too short", "abc12", true},
+		{"too long", "0123456789abcdef0123456789abcdef012345678", true},
+		{"non hex", "placeholder", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &Info{Version: "1.0.0", Commit: tt.commit}
+			err := info.ValidateCommit()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Error(t, info.ValidateStrict())
+			} else {
+				assert.NoError(t, err)
+				assert.NoError(t, info.ValidateStrict())
+			}
+		})
+	}
+}
+
+func TestInfo_ValidateStrict_EmptyVersion(t *testing.T) {
+	info := &Info{Commit: "abc1234"}
+	assert.Error(t, info.ValidateStrict())
+}
+
 func TestInfo_IsDev(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -228,6 +845,134 @@ func TestInfo_IsDev(t *testing.T) {
 	}
 }
 
+func TestInfo_EqualCore(t *testing.T) {
+	a := NewWithBranch("1.2.3", "abc1234", "2025-01-01T00:00:00Z", "main")
+	b := a.clone()
+	b.Platform = "windows/arm64"
+	b.GoVersion = "go1.0"
+	assert.True(t, a.EqualCore(b))
+
+	b.Commit = "def5678"
+	assert.False(t, a.EqualCore(b))
+
+	assert.False(t, a.EqualCore(nil))
+	assert.True(t, (*Info)(nil).EqualCore(nil))
+}
+
+func TestInfo_ValidateAll(t *testing.T) {
+	assert.NoError(t, New("1.2.3", "abc1234", "2025-01-01T00:00:00Z").ValidateAll())
+	assert.NoError(t, New("dev", "unknown", "unknown").ValidateAll())
+
+	err := New("1.2", "xyz", "yesterday").ValidateAll()
+	require.Error(t, err)
+	msg := err.Error()
+	assert.Contains(t, msg, `invalid semantic version "1.2"`)
+	assert.Contains(t, msg, `commit "xyz"`)
+	assert.Contains(t, msg, `build date "yesterday"`)
+
+	err = New("", "", "").ValidateAll()
+	require.Error(t, err)
+	assert.Equal(t, "version is required", err.Error())
+}
+
+func TestInfo_DriftFrom(t *testing.T) {
+	canonical := NewWithBranch("1.2.3", "abc1234", "2025-01-01T00:00:00Z", "main")
+
+	node := canonical.clone()
+	node.Platform = "linux/arm64"
+	node.NumCPU = 64
+	drifted, fields := node.DriftFrom(canonical)
+	assert.False(t, drifted)
+	assert.Empty(t, fields)
+
+	node.Commit = "def5678"
+	drifted, fields = node.DriftFrom(canonical)
+	assert.True(t, drifted)
+	assert.Equal(t, []string{"commit"}, fields)
+
+	node.Branch = ""
+	_, fields = node.DriftFrom(canonical)
+	assert.Equal(t, []string{"branch", "commit"}, fields)
+}
+
+func TestInfo_IsClean(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     *Info
+		expected bool
+	}{
+		{"clean release", &Info{Version: "1.0.0", Commit: "abc123"}, true},
+		{"dirty release", &Info{Version: "1.0.0", Commit: "abc123", Dirty: true}, false},
+		{"dev build", &Info{Version: "dev", Commit: "abc123"}, false},
+		{"dirty dev build", &Info{Version: "dev", Commit: "abc123", Dirty: true}, false},
+		{"unknown commit", &Info{Version: "1.0.0", Commit: "unknown"}, false},
+		{"empty commit", &Info{Version: "1.0.0"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.info.IsClean())
+		})
+	}
+}
+
+func TestInfo_RecencyBucket(t *testing.T) {
+	current := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	origNow := now
+	defer func() { now = origNow }()
+	now = func() time.Time { return current }
+
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{0, "fresh"},
+		{24*time.Hour - time.Second, "fresh"},
+		{24 * time.Hour, "recent"},
+		{7*24*time.Hour - time.Second, "recent"},
+		{7 * 24 * time.Hour, "stale"},
+		{30*24*time.Hour - time.Second, "stale"},
+		{30 * 24 * time.Hour, "old"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.age.String(), func(t *testing.T) {
+			info := &Info{BuildDate: current.Add(-tt.age).Format(time.RFC3339)}
+			assert.Equal(t, tt.want, info.RecencyBucket())
+
+			age, ok := info.BuildAge()
+			assert.True(t, ok)
+			assert.Equal(t, tt.age, age)
+		})
+	}
+
+	assert.Equal(t, "unknown", (&Info{BuildDate: Unknown}).RecencyBucket())
+}
+
+func TestInfo_IsEOL(t *testing.T) {
+	origNow := now
+	defer func() { now = origNow }()
+	now = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name           string
+		supportedUntil string
+		expected       bool
+	}{
+		{"past date", "2025-01-01T00:00:00Z", true},
+		{"future date", "2026-01-01T00:00:00Z", false},
+		{"empty", "", false},
+		{"invalid", "someday", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := NewBuilder().WithVersion("1.0.0").WithSupportedUntil(tt.supportedUntil).Build()
+			assert.Equal(t, tt.expected, info.IsEOL())
+		})
+	}
+}
+
 func TestInfo_BuildTimestamp(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -284,6 +1029,95 @@ func TestInfo_BuildTimestamp(t *testing.T) {
 	}
 }
 
+func TestRegisterBuildDateLayout(t *testing.T) {
+	customLayoutsMu.Lock()
+	orig := customLayouts
+	customLayoutsMu.Unlock()
+	defer func() {
+		customLayoutsMu.Lock()
+		customLayouts = orig
+		customLayoutsMu.Unlock()
+	}()
+
+	info := &Info{BuildDate: "20250315.1045"}
+	assert.True(t, info.BuildTimestamp().IsZero())
+
+	RegisterBuildDateLayout("20060102.1504")
+	RegisterBuildDateLayout("20060102.1504") // duplicates are ignored
+	RegisterBuildDateLayout("")
+
+	assert.Equal(t, time.Date(2025, 3, 15, 10, 45, 0, 0, time.UTC), info.BuildTimestamp())
+
+	customLayoutsMu.RLock()
+	assert.Len(t, customLayouts, len(orig)+1)
+	customLayoutsMu.RUnlock()
+}
+
+func TestInfo_CompileTimestamp(t *testing.T) {
+	info := NewBuilder().
+		WithVersion("1.0.0").
+		WithBuildDate("2025-01-01T12:00:00Z").
+		WithCompileDate("2025-01-02 08:30:00").
+		Build()
+
+	build := info.BuildTimestamp()
+	compile := info.CompileTimestamp()
+
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), build)
+	assert.Equal(t, time.Date(2025, 1, 2, 8, 30, 0, 0, time.UTC), compile)
+
+	info.CompileDate = "unknown"
+	assert.True(t, info.CompileTimestamp().IsZero())
+	assert.Equal(t, build, info.BuildTimestamp())
+
+	info.CompileDate = "not-a-date"
+	assert.True(t, info.CompileTimestamp().IsZero())
+}
+
+func TestInfo_CompileDate_Output(t *testing.T) {
+	info := New("1.0.0", "", "2025-01-01T00:00:00Z")
+	info.CompileDate = "2025-01-02T00:00:00Z"
+
+	assert.Contains(t, info.Full(), "Compiled:   2025-01-02T00:00:00Z")
+	assert.Equal(t, "2025-01-02T00:00:00Z", info.Map()["compile_date"])
+	assert.Contains(t, info.JSON(), `"compile_date":"2025-01-02T00:00:00Z"`)
+
+	info.CompileDate = ""
+	assert.NotContains(t, info.Full(), "Compiled:")
+	_, ok := info.Map()["compile_date"]
+	assert.False(t, ok)
+	assert.NotContains(t, info.JSON(), "compile_date")
+}
+
+func TestInfo_MaskedCommit(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit string
+		want   string
+	}{
+		{"long", "abc1234567890", "abc1…"},
+		{"short", "abc", "abc"},
+		{"exactly four", "abcd", "abcd"},
+		{"unknown", Unknown, ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, (&Info{Commit: tt.commit}).MaskedCommit())
+		})
+	}
+}
+
+func TestUnknown(t *testing.T) {
+	assert.Equal(t, "unknown", Unknown)
+
+	info := New("1.0.0", Unknown, Unknown)
+	assert.Empty(t, info.ShortCommit())
+	assert.NotContains(t, info.Map(), "commit")
+	assert.NotContains(t, info.Full(), "Built:")
+}
+
 func TestInfo_ShortCommit(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -305,22 +1139,97 @@ func TestInfo_ShortCommit(t *testing.T) {
 	}
 }
 
+func TestSetShortCommitLength(t *testing.T) {
+	defer SetShortCommitLength(DefaultShortCommitLength)
+
+	info := New("1.0.0", "abc1234567890def", "")
+
+	SetShortCommitLength(10)
+	assert.Equal(t, 10, ShortCommitLength())
+	assert.Equal(t, "1.0.0 (abc1234567)", info.String())
+	assert.Equal(t, "abc1234567", info.ShortCommit())
+
+	rec := httptest.NewRecorder()
+	setVersionHeaders(rec.Header(), info, "X-", nil)
+	assert.Equal(t, "abc1234567", rec.Header().Get("X-Commit"))
+
+	SetShortCommitLength(1)
+	assert.Equal(t, MinShortCommitLength, ShortCommitLength())
+	assert.Equal(t, "abc1", info.ShortCommit())
+
+	SetShortCommitLength(100)
+	assert.Equal(t, MaxShortCommitLength, ShortCommitLength())
+	assert.Equal(t, "abc1234567890def", info.ShortCommit())
+}
+
 func TestBuilder(t *testing.T) {
 	info := NewBuilder().
 		WithVersion("1.0.0").
 		WithCommit("abc123").
 		WithBuildDate("2025-01-01T00:00:00Z").
 		WithBranch("main").
+		WithDirty(true).
 		Build()
 
 	assert.Equal(t, "1.0.0", info.Version)
 	assert.Equal(t, "abc123", info.Commit)
 	assert.Equal(t, "2025-01-01T00:00:00Z", info.BuildDate)
 	assert.Equal(t, "main", info.Branch)
+	assert.True(t, info.Dirty)
 	assert.Equal(t, runtime.Version(), info.GoVersion)
 	assert.NotEmpty(t, info.Platform)
 }
 
+func TestBuilder_BuildReturnsCopy(t *testing.T) {
+	b := NewBuilder().WithVersion("1.0.0").WithExtra("region", "eu")
+
+	first := b.Build()
+	first.Version = "mutated"
+	first.Extra["region"] = "us"
+
+	second := b.WithCommit("abc123").Build()
+
+	assert.NotSame(t, first, second)
+	assert.Equal(t, "1.0.0", second.Version)
+	assert.Equal(t, "eu", second.Extra["region"])
+	assert.Equal(t, "abc123", second.Commit)
+	assert.Empty(t, first.Commit)
+}
+
+func TestBuilder_WithBuildDateValidated(t *testing.T) {
+	info, err := NewBuilder().WithVersion("1.0.0").WithBuildDateValidated("2025-01-01T00:00:00Z").BuildValidated()
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-01T00:00:00Z", info.BuildDate)
+
+	b := NewBuilder().WithVersion("1.0.0").WithBuildDateValidated("last tuesday")
+	info, err = b.BuildValidated()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"last tuesday"`)
+	assert.Equal(t, "last tuesday", info.BuildDate)
+
+	_, err = b.Reset().BuildValidated()
+	assert.NoError(t, err)
+}
+
+func TestBuilder_Reset(t *testing.T) {
+	b := NewBuilder()
+
+	first := b.WithVersion("1.0.0").WithCommit("abc123").WithExtra("k", "v").Build()
+	second := b.Reset().WithVersion("2.0.0").Build()
+
+	assert.NotSame(t, first, second)
+	assert.Equal(t, "1.0.0", first.Version)
+	assert.Equal(t, "abc123", first.Commit)
+	assert.Equal(t, "v", first.Extra["k"])
+
+	assert.Equal(t, "2.0.0", second.Version)
+	assert.Empty(t, second.Commit)
+	assert.Nil(t, second.Extra)
+	assert.Equal(t, runtime.Version(), second.GoVersion)
+	assert.NotEmpty(t, second.Platform)
+	assert.NotEmpty(t, second.Compiler)
+}
+
 func TestVersionVariablesNotEmpty(t *testing.T) {
 	// Default values should not be empty
 	if Version == "" {
@@ -376,3 +1285,112 @@ func TestInfo_BuildTimestamp_RFC1123(t *testing.T) {
 	ts := info.BuildTimestamp()
 	assert.False(t, ts.IsZero())
 }
+
+func TestInfo_BuildTimestamp_ANSIC(t *testing.T) {
+	info := &Info{BuildDate: "Mon Jan 02 15:04:05 2006"}
+	assert.Equal(t, time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), info.BuildTimestamp())
+
+	info.BuildDate = "Mon Jan  2 15:04:05 UTC 2006"
+	assert.False(t, info.BuildTimestamp().IsZero())
+}
+
+func TestInfo_PlatformParts(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		wantOS   string
+		wantArch string
+		wantOK   bool
+	}{
+		{"linux/amd64", "linux/amd64", "linux", "amd64", true},
+		{"darwin/arm64", "darwin/arm64", "darwin", "arm64", true},
+		{"empty", "", "", "", false},
+		{"no separator", "linux", "", "", false},
+		{"missing arch", "linux/", "", "", false},
+		{"missing os", "/amd64", "", "", false},
+		{"too many parts", "linux/arm/v7", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &Info{Platform: tt.platform}
+			gotOS, gotArch, ok := info.PlatformParts()
+			assert.Equal(t, tt.wantOS, gotOS)
+			assert.Equal(t, tt.wantArch, gotArch)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantOS, info.OS())
+			assert.Equal(t, tt.wantArch, info.Arch())
+		})
+	}
+}
+
+func TestInfo_OSArch_Runtime(t *testing.T) {
+	info := New("1.0.0", "", "")
+	assert.Equal(t, runtime.GOOS, info.OS())
+	assert.Equal(t, runtime.GOARCH, info.Arch())
+}
+
+func TestInfo_Platforms(t *testing.T) {
+	info := &Info{Platform: "darwin/amd64, darwin/arm64"}
+	assert.Equal(t, []string{"darwin/amd64", "darwin/arm64"}, info.Platforms())
+	assert.Equal(t, "darwin/amd64", info.PrimaryPlatform())
+	assert.Equal(t, "darwin", info.OS())
+	assert.Equal(t, "amd64", info.Arch())
+
+	empty := &Info{}
+	assert.Empty(t, empty.Platforms())
+	assert.Empty(t, empty.PrimaryPlatform())
+}
+
+func TestInfo_MatchesRuntime(t *testing.T) {
+	assert.True(t, New("1.0.0", "", "").MatchesRuntime())
+
+	mismatched := "darwin/arm64"
+	if runtime.GOOS == "darwin" && runtime.GOARCH == "arm64" {
+		mismatched = "linux/amd64"
+	}
+
+	tests := []struct {
+		name     string
+		platform string
+		expected bool
+	}{
+		{"matching", runtime.GOOS + "/" + runtime.GOARCH, true},
+		{"mismatched", mismatched, false},
+		{"same os other arch", runtime.GOOS + "/not-an-arch", false},
+		{"malformed", runtime.GOOS, false},
+		{"empty", "", false},
+		{"listed second", mismatched + "," + runtime.GOOS + "/" + runtime.GOARCH, true},
+		{"none listed", mismatched + "," + runtime.GOOS + "/not-an-arch", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &Info{Platform: tt.platform}
+			assert.Equal(t, tt.expected, info.MatchesRuntime())
+		})
+	}
+}
+
+func TestLogOnce(t *testing.T) {
+	logOnce = sync.Once{}
+	defer func() { logOnce = sync.Once{} }()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	info := New("1.0.0", "abc123", "")
+
+	for range 5 {
+		LogOnce(logger, info)
+	}
+	LogOnce(logger, New("2.0.0", "", ""))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "version info", record["msg"])
+	assert.Equal(t, "1.0.0", record["version"])
+	assert.Equal(t, "abc123", record["commit"])
+}